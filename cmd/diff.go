@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	diffcmd "github.com/vcsomor/aws-resources/internal/diff/cmd"
+)
+
+func diffCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "diff <old-dir|file> <new-dir|file>",
+		Short: "Compare two scans.",
+		Long: `Comparing two scans written by the file, the stdout, the yaml or the ndjson output. The resources are matched by ARN
+and the added, removed and changed resources are printed with the changed fields.`,
+		Args: cobra.ExactArgs(2),
+		Run:  diffcmd.DiffScans,
+	}
+}
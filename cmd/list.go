@@ -1,8 +1,16 @@
 package cmd
 
 import (
+	"fmt"
 	"github.com/spf13/cobra"
+	"github.com/vcsomor/aws-resources/config"
+	"github.com/vcsomor/aws-resources/internal/lister"
+	"github.com/vcsomor/aws-resources/internal/lister/args"
 	listcmd "github.com/vcsomor/aws-resources/internal/lister/cmd"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 )
 
 func listCommand() *cobra.Command {
@@ -10,7 +18,7 @@ func listCommand() *cobra.Command {
 		Use:   "list",
 		Short: "List AWS resources.",
 		Long:  "Listing the AWS Resources",
-		Run:   listcmd.ListResources,
+		Run:   interruptible(listcmd.ListResources),
 	}
 
 	cmd.PersistentFlags().
@@ -19,6 +27,12 @@ func listCommand() *cobra.Command {
 			"2",
 			`Specify thread count for querying the resources.`)
 
+	cmd.PersistentFlags().
+		String(
+			"page-size",
+			"0",
+			`Specify the number of resources requested per page by the paginated listings e.g.: --page-size 50. Use "0" for the service defaults. RDS accepts 20-100 and EC2 5-1000, other values are moved into these ranges.`)
+
 	cmd.PersistentFlags().
 		String(
 			"regions",
@@ -29,19 +43,138 @@ func listCommand() *cobra.Command {
 		String(
 			"resources",
 			"all",
-			`Specify resources to list for e.g.: --resources s3,rds. Use "all" for every supported resource type.`)
+			fmt.Sprintf(
+				`Specify resources to list for e.g.: --resources s3,rds. Possible values are: %s. Use "all" for every supported resource type.`,
+				quoted(lister.ResourceTypes())))
 
 	cmd.PersistentFlags().
 		String(
 			"output",
 			"stdout",
-			`Specify the output  e.g.: --output stdout,file. Possible values are: "stdout", "file"`)
+			`Specify the output  e.g.: --output stdout,file. Possible values are: "stdout", "file", "csv", "ndjson", "yaml", "sqlite", "html", "table", "markdown", "template". Only one of "stdout", "ndjson", "yaml", "table", "markdown" and "template" can be selected as they print on the standard output.`)
 
 	cmd.PersistentFlags().
 		String(
 			"target",
 			"resources",
-			`Specify the target directory if file output has been specified.`)
+			`Specify the target directory if file or csv output has been specified.`)
+
+	cmd.PersistentFlags().
+		String(
+			"format",
+			args.FormatDefault,
+			fmt.Sprintf(
+				`Specify the format of the file output e.g.: --format ndjson. Possible values are: %s`,
+				quoted(args.Formats())))
+
+	cmd.PersistentFlags().
+		String(
+			"columns",
+			"",
+			`Specify the property columns of the table and markdown outputs e.g.: --columns properties.engine,properties.status`)
+
+	cmd.PersistentFlags().
+		String(
+			"template",
+			"",
+			`Specify the text/template file of the template output e.g.: --template wiki.tmpl`)
+
+	cmd.PersistentFlags().
+		String(
+			"rate-limit",
+			"",
+			`Specify the request rate per service e.g.: --rate-limit s3=20/s,rds=5/s or for a region e.g.: s3/eu-west-1=5/s. The rates apply in every region separately.`)
+
+	cmd.PersistentFlags().
+		String(
+			"tags",
+			"",
+			`Specify the tags the listed resources must have e.g.: --tags Environment=prod,Owner. A tag without a value matches any value.`)
+
+	cmd.PersistentFlags().
+		String(
+			"config",
+			"",
+			fmt.Sprintf(`Specify the config file holding the scan profiles. The default is ~/%s`, config.DefaultScanConfigFile))
+
+	cmd.PersistentFlags().
+		String(
+			"scan-profile",
+			"",
+			`Specify the scan profile of the config file e.g.: --scan-profile nightly. The flags override the values of the profile. The "default" profile is used if there is one.`)
+
+	cmd.PersistentFlags().
+		String(
+			"aws-profile",
+			"",
+			`Specify the named profile of the shared AWS config files e.g.: --aws-profile inventory. The default credential chain is used without it.`)
+
+	cmd.PersistentFlags().
+		String(
+			"assume-role-arn",
+			"",
+			`Specify the role to assume for the listing e.g.: --assume-role-arn arn:aws:iam::123456789012:role/inventory`)
+
+	cmd.PersistentFlags().
+		String(
+			"external-id",
+			"",
+			`Specify the external ID the role requires.`)
+
+	cmd.PersistentFlags().
+		String(
+			"role-session-name",
+			"",
+			`Specify the session name of the assumed role shown in CloudTrail. The default is "aws-resources-<unix time>".`)
+
+	cmd.PersistentFlags().
+		String(
+			"role-duration",
+			"",
+			`Specify how long the session of the assumed role lasts e.g.: --role-duration 2h. The default is 15 minutes, the maximum is the maximum session duration of the role. A role session with MFA is not renewed, the scan has to finish within it.`)
+
+	cmd.PersistentFlags().
+		String(
+			"mfa-serial",
+			"",
+			`Specify the serial number or the ARN of the MFA device the role requires.`)
+
+	cmd.PersistentFlags().
+		String(
+			"mfa-token",
+			"",
+			`Specify the current code of the MFA device. It is asked for on the terminal if the role requires MFA and no code is given. The code is used once, see --role-duration.`)
+
+	cmd.PersistentFlags().
+		String(
+			"task-timeout",
+			"0s",
+			`Specify the time limit of a listing task e.g.: --task-timeout 30s. A task lists every page of a resource type in a region, or the location and the tags of an S3 bucket. Use "0s" for no limit.`)
 
 	return &cmd
 }
+
+// interruptible cancels the command context on the first SIGINT or SIGTERM so the command can
+// write its partial results, a second signal terminates the process as usual
+func interruptible(run func(*cobra.Command, []string)) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		go func() {
+			<-ctx.Done()
+			stop()
+		}()
+
+		cmd.SetContext(ctx)
+		run(cmd, args)
+	}
+}
+
+func quoted(values []string) string {
+	var q []string
+	for _, v := range values {
+		q = append(q, fmt.Sprintf("%q", v))
+	}
+	return strings.Join(q, ", ")
+}
@@ -24,6 +24,7 @@ to quickly create a Cobra application.`,
 	rootCmd.AddCommand(
 		versionCommand(),
 		listCommand(),
+		diffCommand(),
 	)
 
 	if err := rootCmd.Execute(); err != nil {
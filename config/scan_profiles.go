@@ -0,0 +1,102 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultScanConfigFile is the name of the config file in the home directory
+const DefaultScanConfigFile = ".aws-resources.yaml"
+
+// ScanConfig is the content of the config file: the named scan profiles
+type ScanConfig struct {
+	Profiles map[string]ScanProfile `yaml:"profiles"`
+}
+
+// ScanProfile is a named scan definition, its values are used for the flags not given on the command line
+type ScanProfile struct {
+	Regions     []string    `yaml:"regions"`
+	Resources   []string    `yaml:"resources"`
+	Outputs     []string    `yaml:"outputs"`
+	Target      string      `yaml:"target"`
+	Format      string      `yaml:"format"`
+	Columns     []string    `yaml:"columns"`
+	Template    string      `yaml:"template"`
+	Threads     int         `yaml:"threads"`
+	PageSize    int         `yaml:"pageSize"`
+	TaskTimeout string      `yaml:"taskTimeout"`
+	RateLimits  []string    `yaml:"rateLimits"`
+	Filters     ScanFilters `yaml:"filters"`
+
+	AWSProfile      string `yaml:"awsProfile"`
+	AssumeRoleArn   string `yaml:"assumeRoleArn"`
+	ExternalID      string `yaml:"externalId"`
+	RoleSessionName string `yaml:"roleSessionName"`
+	RoleDuration    string `yaml:"roleDuration"`
+	MFASerial       string `yaml:"mfaSerial"`
+}
+
+// ScanFilters narrow down the listed resources
+type ScanFilters struct {
+	// Tags keeps the resources having every tag, a tag without a value matches any value
+	Tags map[string]*string `yaml:"tags"`
+}
+
+// DefaultScanConfigPath returns the config file in the home directory, empty if there is no home directory
+func DefaultScanConfigPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, DefaultScanConfigFile)
+}
+
+// LoadScanConfig reads the config file, a missing file is an empty config unless it is required
+func LoadScanConfig(path string, required bool) (ScanConfig, error) {
+	if path == "" {
+		return ScanConfig{}, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && !required {
+		return ScanConfig{}, nil
+	}
+	if err != nil {
+		return ScanConfig{}, fmt.Errorf("unable to read the config file: %w", err)
+	}
+
+	var c ScanConfig
+	dec := yaml.NewDecoder(bytes.NewReader(b))
+	// a misspelled setting would be silently ignored otherwise
+	dec.KnownFields(true)
+	if err = dec.Decode(&c); err != nil && !errors.Is(err, io.EOF) {
+		return ScanConfig{}, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return c, nil
+}
+
+// Profile returns the named scan profile
+func (c ScanConfig) Profile(name string) (ScanProfile, error) {
+	p, exist := c.Profiles[name]
+	if !exist {
+		return ScanProfile{}, fmt.Errorf("unknown scan profile %q, the known profiles are: %v", name, c.ProfileNames())
+	}
+	return p, nil
+}
+
+// ProfileNames returns the names of the scan profiles in alphabetical order
+func (c ScanConfig) ProfileNames() []string {
+	var names []string
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
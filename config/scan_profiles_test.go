@@ -0,0 +1,86 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), DefaultScanConfigFile)
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadScanConfig(t *testing.T) {
+	c, err := LoadScanConfig(writeConfig(t, `
+profiles:
+  nightly:
+    regions: [eu-west-1, us-east-1]
+    resources: [s3, rds]
+    outputs: [file, sqlite]
+    target: /var/inventory
+    threads: 16
+    rateLimits: [s3=20/s]
+    awsProfile: inventory
+    assumeRoleArn: arn:aws:iam::123456789012:role/inventory
+    externalId: audit
+    filters:
+      tags:
+        Environment: prod
+        Owner:
+  quick:
+    outputs: [table]
+`), true)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"nightly", "quick"}, c.ProfileNames())
+
+	p, err := c.Profile("nightly")
+	assert.Nil(t, err)
+	prod := "prod"
+	assert.Equal(t,
+		ScanProfile{
+			Regions:    []string{"eu-west-1", "us-east-1"},
+			Resources:  []string{"s3", "rds"},
+			Outputs:    []string{"file", "sqlite"},
+			Target:     "/var/inventory",
+			Threads:    16,
+			RateLimits: []string{"s3=20/s"},
+			Filters: ScanFilters{
+				Tags: map[string]*string{"Environment": &prod, "Owner": nil},
+			},
+			AWSProfile:    "inventory",
+			AssumeRoleArn: "arn:aws:iam::123456789012:role/inventory",
+			ExternalID:    "audit",
+		},
+		p)
+
+	_, err = c.Profile("weekly")
+	assert.EqualError(t, err, `unknown scan profile "weekly", the known profiles are: [nightly quick]`)
+}
+
+func TestMissingScanConfig(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), DefaultScanConfigFile)
+
+	c, err := LoadScanConfig(missing, false)
+	assert.Nil(t, err)
+	assert.Empty(t, c.Profiles)
+
+	_, err = LoadScanConfig(missing, true)
+	assert.NotNil(t, err)
+
+	c, err = LoadScanConfig(writeConfig(t, ""), true)
+	assert.Nil(t, err)
+	assert.Empty(t, c.Profiles)
+}
+
+func TestInvalidScanConfig(t *testing.T) {
+	_, err := LoadScanConfig(writeConfig(t, `
+profiles:
+  nightly:
+    region: [eu-west-1]
+`), true)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,145 @@
+package aws_connector
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultRoleSessionName = "aws-resources"
+
+// ErrMFATokenUsed is returned when the role session expires during a scan with MFA, an MFA code can be
+// used only once so the role can't be assumed again without a new code
+var ErrMFATokenUsed = errors.New("the MFA token code has already been used, the role session expired before the scan finished: use a longer session duration")
+
+// AssumeRole is the role the clients assume with the loaded credentials
+type AssumeRole struct {
+	RoleArn    string
+	ExternalID string
+	// SessionName shows up in CloudTrail, the default is "aws-resources-<unix time>"
+	SessionName string
+	// Duration is how long the role session lasts, zero means the 15 minutes default. The role session
+	// with MFA is not renewed, the scan has to finish within it.
+	Duration time.Duration
+
+	// MFASerial is the serial number or the ARN of the MFA device the role requires
+	MFASerial string
+	// MFAToken is the current code of the MFA device, it is asked for on the terminal if it is empty
+	MFAToken string
+}
+
+// DefaultRoleSessionName returns the session name used when none is given
+func DefaultRoleSessionName(now time.Time) string {
+	return fmt.Sprintf("%s-%d", defaultRoleSessionName, now.Unix())
+}
+
+func assumeRoleProvider(client stscreds.AssumeRoleAPIClient, r AssumeRole) aws.CredentialsProvider {
+	var token *mfaToken
+	if r.MFASerial != "" {
+		token = newMFAToken(r.MFAToken, os.Stdin, os.Stderr)
+	}
+
+	provider := stscreds.NewAssumeRoleProvider(client, r.RoleArn, func(o *stscreds.AssumeRoleOptions) {
+		o.RoleSessionName = r.SessionName
+		if o.RoleSessionName == "" {
+			o.RoleSessionName = DefaultRoleSessionName(time.Now())
+		}
+		if r.ExternalID != "" {
+			o.ExternalID = &r.ExternalID
+		}
+		if r.Duration > 0 {
+			o.Duration = r.Duration
+		}
+		if r.MFASerial != "" {
+			serial := r.MFASerial
+			o.SerialNumber = &serial
+			o.TokenProvider = token.code
+		}
+	})
+	if token == nil {
+		return provider
+	}
+	return &mfaRoleProvider{provider: provider, token: token}
+}
+
+// mfaRoleProvider tells the MFA token whether the role could be assumed with it
+type mfaRoleProvider struct {
+	provider aws.CredentialsProvider
+	token    *mfaToken
+}
+
+func (p *mfaRoleProvider) Retrieve(ctx context.Context) (aws.Credentials, error) {
+	creds, err := p.provider.Retrieve(ctx)
+	p.token.assumed(err)
+	return creds, err
+}
+
+// mfaToken hands out the given token, or asks for it on the terminal. The question goes to the standard
+// error as the standard output may carry the inventory. Once the role has been assumed with the token,
+// renewing the role session fails with ErrMFATokenUsed instead of reusing the code or asking in the
+// middle of a scan. A code typed on the terminal is asked for again if the role could not be assumed with it.
+type mfaToken struct {
+	mu       sync.Mutex
+	token    string
+	prompted bool
+	used     bool
+	in       *bufio.Reader
+	out      io.Writer
+}
+
+func newMFAToken(token string, in io.Reader, out io.Writer) *mfaToken {
+	return &mfaToken{
+		token: token,
+		in:    bufio.NewReader(in),
+		out:   out,
+	}
+}
+
+func (t *mfaToken) code() (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.used {
+		return "", ErrMFATokenUsed
+	}
+	if t.token == "" {
+		token, err := readMFAToken(t.in, t.out)
+		if err != nil {
+			return "", err
+		}
+		t.token, t.prompted = token, true
+	}
+	return t.token, nil
+}
+
+// assumed records the outcome of assuming the role with the token
+func (t *mfaToken) assumed(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	switch {
+	case err == nil:
+		t.used = true
+	case t.prompted:
+		t.token, t.prompted = "", false
+	}
+}
+
+func readMFAToken(in *bufio.Reader, out io.Writer) (string, error) {
+	if _, err := fmt.Fprint(out, "MFA token code: "); err != nil {
+		return "", err
+	}
+	line, err := in.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("unable to read the MFA token code: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
@@ -0,0 +1,150 @@
+package aws_connector
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/aws-sdk-go-v2/service/sts/types"
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeAssumeRoleAPI struct {
+	in     *sts.AssumeRoleInput
+	tokens []string
+	fail   int
+}
+
+func (f *fakeAssumeRoleAPI) AssumeRole(
+	_ context.Context,
+	in *sts.AssumeRoleInput,
+	_ ...func(*sts.Options),
+) (*sts.AssumeRoleOutput, error) {
+	f.in = in
+	if in.TokenCode != nil {
+		f.tokens = append(f.tokens, *in.TokenCode)
+	}
+	if f.fail > 0 {
+		f.fail--
+		return nil, errors.New("MultiFactorAuthentication failed with invalid MFA one time pass code")
+	}
+	return &sts.AssumeRoleOutput{
+		Credentials: &types.Credentials{
+			AccessKeyId:     ptr.String("ASIAEXAMPLE"),
+			SecretAccessKey: ptr.String("secret"),
+			SessionToken:    ptr.String("token"),
+			Expiration:      ptr.Time(time.Now().Add(time.Hour)),
+		},
+	}, nil
+}
+
+func TestAssumeRole(t *testing.T) {
+	api := &fakeAssumeRoleAPI{}
+	creds, err := assumeRoleProvider(api, AssumeRole{
+		RoleArn:     "arn:aws:iam::123456789012:role/inventory",
+		ExternalID:  "audit-2024",
+		SessionName: "nightly",
+		Duration:    2 * time.Hour,
+		MFASerial:   "arn:aws:iam::123456789012:mfa/scanner",
+		MFAToken:    "123456",
+	}).Retrieve(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, "ASIAEXAMPLE", creds.AccessKeyID)
+	assert.Equal(t, "arn:aws:iam::123456789012:role/inventory", *api.in.RoleArn)
+	assert.Equal(t, "audit-2024", *api.in.ExternalId)
+	assert.Equal(t, "nightly", *api.in.RoleSessionName)
+	assert.Equal(t, int32(7200), *api.in.DurationSeconds)
+	assert.Equal(t, "arn:aws:iam::123456789012:mfa/scanner", *api.in.SerialNumber)
+	assert.Equal(t, "123456", *api.in.TokenCode)
+}
+
+func TestAssumeRoleDefaults(t *testing.T) {
+	api := &fakeAssumeRoleAPI{}
+	_, err := assumeRoleProvider(api, AssumeRole{
+		RoleArn: "arn:aws:iam::123456789012:role/inventory",
+	}).Retrieve(context.Background())
+
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(*api.in.RoleSessionName, "aws-resources-"))
+	assert.Nil(t, api.in.ExternalId)
+	assert.Nil(t, api.in.SerialNumber)
+	assert.Nil(t, api.in.TokenCode)
+	assert.Equal(t, int32(900), *api.in.DurationSeconds)
+}
+
+func TestMFATokenIsNotReused(t *testing.T) {
+	api := &fakeAssumeRoleAPI{}
+	provider := assumeRoleProvider(api, AssumeRole{
+		RoleArn:   "arn:aws:iam::123456789012:role/inventory",
+		MFASerial: "arn:aws:iam::123456789012:mfa/scanner",
+		MFAToken:  "123456",
+	})
+
+	_, err := provider.Retrieve(context.Background())
+	assert.Nil(t, err)
+
+	_, err = provider.Retrieve(context.Background())
+	assert.ErrorIs(t, err, ErrMFATokenUsed)
+}
+
+func TestMFATokenFromTerminal(t *testing.T) {
+	var out bytes.Buffer
+	token, err := newMFAToken("", strings.NewReader("654321\n"), &out).code()
+	assert.Nil(t, err)
+	assert.Equal(t, "654321", token)
+	assert.Equal(t, "MFA token code: ", out.String())
+
+	_, err = newMFAToken("", strings.NewReader(""), &out).code()
+	assert.NotNil(t, err)
+}
+
+func TestMFATokenAfterFailedAssumeRole(t *testing.T) {
+	// a mistyped code is asked for again
+	var out bytes.Buffer
+	token := newMFAToken("", strings.NewReader("111111\n654321\n"), &out)
+	api := &fakeAssumeRoleAPI{fail: 1}
+	provider := &mfaRoleProvider{
+		provider: stscreds.NewAssumeRoleProvider(api, "arn:aws:iam::123456789012:role/inventory",
+			func(o *stscreds.AssumeRoleOptions) {
+				o.SerialNumber = ptr.String("arn:aws:iam::123456789012:mfa/scanner")
+				o.TokenProvider = token.code
+			}),
+		token: token,
+	}
+
+	_, err := provider.Retrieve(context.Background())
+	assert.ErrorContains(t, err, "invalid MFA one time pass code")
+	assert.NotErrorIs(t, err, ErrMFATokenUsed)
+
+	_, err = provider.Retrieve(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"111111", "654321"}, api.tokens)
+	assert.Equal(t, "MFA token code: MFA token code: ", out.String())
+
+	_, err = provider.Retrieve(context.Background())
+	assert.ErrorIs(t, err, ErrMFATokenUsed)
+
+	// a given code is retried after a transient failure
+	api = &fakeAssumeRoleAPI{fail: 1}
+	given := assumeRoleProvider(api, AssumeRole{
+		RoleArn:   "arn:aws:iam::123456789012:role/inventory",
+		MFASerial: "arn:aws:iam::123456789012:mfa/scanner",
+		MFAToken:  "123456",
+	})
+
+	_, err = given.Retrieve(context.Background())
+	assert.NotErrorIs(t, err, ErrMFATokenUsed)
+	_, err = given.Retrieve(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"123456", "123456"}, api.tokens)
+}
+
+func TestDefaultRoleSessionName(t *testing.T) {
+	assert.Equal(t, "aws-resources-1714557600", DefaultRoleSessionName(time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)))
+}
@@ -0,0 +1,120 @@
+package aws_connector
+
+import (
+	"context"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"time"
+)
+
+type ListEC2Params struct {
+	// PageSize is the number of instances requested per page, zero means the service default (1000).
+	// The service accepts values between 5 and 1000, the other values are moved into this range.
+	PageSize int32
+}
+
+type ListEC2Result struct {
+	Arn        string
+	ID         string
+	LaunchTime *time.Time
+
+	InstanceType       string
+	State              string
+	ImageID            *string
+	VpcID              *string
+	SubnetID           *string
+	PrivateIPAddress   *string
+	PublicIPAddress    *string
+	IamInstanceProfile *string
+
+	Tags map[string]*string
+}
+
+type EC2Client interface {
+	List(ctx context.Context, p ListEC2Params) ([]ListEC2Result, error)
+}
+
+type ec2Client struct {
+	client ec2.DescribeInstancesAPIClient
+	region string
+}
+
+var _ EC2Client = (*ec2Client)(nil)
+
+func newEC2Client(client *ec2.Client) EC2Client {
+	return &ec2Client{
+		client: client,
+		region: client.Options().Region,
+	}
+}
+
+func (c *ec2Client) List(ctx context.Context, p ListEC2Params) ([]ListEC2Result, error) {
+	var res []ListEC2Result
+
+	paginator := ec2.NewDescribeInstancesPaginator(
+		c.client,
+		&ec2.DescribeInstancesInput{},
+		func(o *ec2.DescribeInstancesPaginatorOptions) {
+			if p.PageSize > 0 {
+				o.Limit = clampPageSize(p.PageSize, 5, 1000)
+			}
+		})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, reservation := range page.Reservations {
+			owner := safeDeref(reservation.OwnerId, "")
+			for _, i := range reservation.Instances {
+				res = append(res, c.toResult(owner, i))
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func (c *ec2Client) toResult(owner string, i types.Instance) ListEC2Result {
+	id := safeDeref(i.InstanceId, "")
+
+	var state string
+	if i.State != nil {
+		state = string(i.State.Name)
+	}
+
+	var profile *string
+	if i.IamInstanceProfile != nil {
+		profile = i.IamInstanceProfile.Arn
+	}
+
+	return ListEC2Result{
+		Arn:        fmt.Sprintf("arn:aws:ec2:%s:%s:instance/%s", c.region, owner, id),
+		ID:         id,
+		LaunchTime: i.LaunchTime,
+
+		InstanceType:       string(i.InstanceType),
+		State:              state,
+		ImageID:            i.ImageId,
+		VpcID:              i.VpcId,
+		SubnetID:           i.SubnetId,
+		PrivateIPAddress:   i.PrivateIpAddress,
+		PublicIPAddress:    i.PublicIpAddress,
+		IamInstanceProfile: profile,
+
+		Tags: transformEC2Tags(i.Tags),
+	}
+}
+
+func transformEC2Tags(tags []types.Tag) map[string]*string {
+	res := map[string]*string{}
+	for _, t := range tags {
+		if t.Key == nil {
+			continue
+		}
+		res[*t.Key] = t.Value
+	}
+	return res
+}
@@ -0,0 +1,114 @@
+package aws_connector
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type fakeDescribeInstances struct {
+	pages      []*ec2.DescribeInstancesOutput
+	err        error
+	calls      int
+	maxResults []*int32
+}
+
+func (f *fakeDescribeInstances) DescribeInstances(
+	_ context.Context,
+	in *ec2.DescribeInstancesInput,
+	_ ...func(*ec2.Options),
+) (*ec2.DescribeInstancesOutput, error) {
+	f.calls++
+	f.maxResults = append(f.maxResults, in.MaxResults)
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	idx := 0
+	if in.NextToken != nil {
+		idx = int((*in.NextToken)[0] - '0')
+	}
+	return f.pages[idx], nil
+}
+
+func TestEC2ListAllPages(t *testing.T) {
+	fake := &fakeDescribeInstances{
+		pages: []*ec2.DescribeInstancesOutput{
+			{
+				Reservations: []types.Reservation{{
+					OwnerId: ptr.String("123456789012"),
+					Instances: []types.Instance{{
+						InstanceId:   ptr.String("i-1"),
+						InstanceType: types.InstanceTypeT3Micro,
+						State:        &types.InstanceState{Name: types.InstanceStateNameRunning},
+						ImageId:      ptr.String("ami-1"),
+						IamInstanceProfile: &types.IamInstanceProfile{
+							Arn: ptr.String("arn:aws:iam::123456789012:instance-profile/web"),
+						},
+						Tags: []types.Tag{
+							{Key: ptr.String("Name"), Value: ptr.String("web")},
+							{Value: ptr.String("no key")},
+						},
+					}},
+				}},
+				NextToken: ptr.String("1"),
+			},
+			{
+				Reservations: []types.Reservation{{
+					OwnerId: ptr.String("123456789012"),
+					Instances: []types.Instance{{
+						InstanceId: ptr.String("i-2"),
+					}},
+				}},
+			},
+		},
+	}
+
+	c := &ec2Client{client: fake, region: "eu-west-1"}
+	res, err := c.List(context.Background(), ListEC2Params{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, fake.calls)
+	assert.Equal(t, []*int32{nil, nil}, fake.maxResults)
+
+	assert.Equal(t,
+		[]ListEC2Result{
+			{
+				Arn:                "arn:aws:ec2:eu-west-1:123456789012:instance/i-1",
+				ID:                 "i-1",
+				InstanceType:       "t3.micro",
+				State:              "running",
+				ImageID:            ptr.String("ami-1"),
+				IamInstanceProfile: ptr.String("arn:aws:iam::123456789012:instance-profile/web"),
+				Tags:               map[string]*string{"Name": ptr.String("web")},
+			},
+			{
+				Arn:  "arn:aws:ec2:eu-west-1:123456789012:instance/i-2",
+				ID:   "i-2",
+				Tags: map[string]*string{},
+			},
+		},
+		res)
+}
+
+func TestEC2ListError(t *testing.T) {
+	fake := &fakeDescribeInstances{err: errors.New("boom")}
+
+	c := &ec2Client{client: fake, region: "eu-west-1"}
+	_, err := c.List(context.Background(), ListEC2Params{})
+	assert.ErrorContains(t, err, "boom")
+}
+
+func TestEC2ListPageSize(t *testing.T) {
+	for size, want := range map[int32]int32{50: 50, 1: 5, 5000: 1000} {
+		fake := &fakeDescribeInstances{pages: []*ec2.DescribeInstancesOutput{{}}}
+
+		c := &ec2Client{client: fake, region: "eu-west-1"}
+		_, err := c.List(context.Background(), ListEC2Params{PageSize: size})
+		assert.Nil(t, err)
+		assert.Equal(t, []*int32{ptr.Int32(want)}, fake.maxResults)
+	}
+}
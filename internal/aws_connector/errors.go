@@ -0,0 +1,75 @@
+package aws_connector
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go"
+	"slices"
+	"strings"
+)
+
+type ErrorClass string
+
+const (
+	ErrorClassNotFound     ErrorClass = "not-found"
+	ErrorClassAccessDenied ErrorClass = "access-denied"
+	ErrorClassThrottled    ErrorClass = "throttled"
+	ErrorClassCanceled     ErrorClass = "canceled"
+	ErrorClassTimeout      ErrorClass = "timeout"
+	ErrorClassOther        ErrorClass = "other"
+)
+
+const errorCodeNoSuchTagSet = "NoSuchTagSet"
+
+var accessDeniedErrorCodes = []string{
+	"AccessDenied",
+	"AccessDeniedException",
+	"AllAccessDisabled",
+	"AuthorizationError",
+	"Forbidden",
+	"UnauthorizedOperation",
+}
+
+// ClassifyError tells what kind of failure an AWS API call returned, errors which are
+// not coming from the AWS API are classified as ErrorClassOther unless the call was
+// canceled or timed out
+func ClassifyError(err error) ErrorClass {
+	if err == nil {
+		return ""
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ErrorClassCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return ErrorClassTimeout
+	}
+
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return ErrorClassOther
+	}
+
+	code := apiErr.ErrorCode()
+	switch {
+	case slices.Contains(accessDeniedErrorCodes, code):
+		return ErrorClassAccessDenied
+	case isThrottleErrorCode(code):
+		return ErrorClassThrottled
+	case strings.HasPrefix(code, "NoSuch") || strings.HasSuffix(code, "NotFound") || strings.HasSuffix(code, "NotFoundFault"):
+		return ErrorClassNotFound
+	default:
+		return ErrorClassOther
+	}
+}
+
+func isThrottleErrorCode(code string) bool {
+	_, throttle := retry.DefaultThrottleErrorCodes[code]
+	return throttle
+}
+
+func hasErrorCode(err error, code string) bool {
+	var apiErr smithy.APIError
+	return errors.As(err, &apiErr) && apiErr.ErrorCode() == code
+}
@@ -0,0 +1,45 @@
+package aws_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestClassifyError(t *testing.T) {
+	apiError := func(code string) error {
+		return &smithy.OperationError{
+			ServiceID:     "S3",
+			OperationName: "GetBucketTagging",
+			Err:           &smithy.GenericAPIError{Code: code},
+		}
+	}
+
+	assert.Equal(t, ErrorClass(""), ClassifyError(nil))
+
+	assert.Equal(t, ErrorClassNotFound, ClassifyError(apiError("NoSuchTagSet")))
+	assert.Equal(t, ErrorClassNotFound, ClassifyError(apiError("NoSuchBucket")))
+	assert.Equal(t, ErrorClassNotFound, ClassifyError(apiError("DBInstanceNotFound")))
+	assert.Equal(t, ErrorClassNotFound, ClassifyError(apiError("DBInstanceNotFoundFault")))
+
+	assert.Equal(t, ErrorClassAccessDenied, ClassifyError(apiError("AccessDenied")))
+	assert.Equal(t, ErrorClassAccessDenied, ClassifyError(apiError("UnauthorizedOperation")))
+	assert.Equal(t, ErrorClassAccessDenied, ClassifyError(fmt.Errorf("wrapped: %w", apiError("AccessDeniedException"))))
+
+	assert.Equal(t, ErrorClassThrottled, ClassifyError(apiError("SlowDown")))
+	assert.Equal(t, ErrorClassThrottled, ClassifyError(apiError("ThrottlingException")))
+	assert.Equal(t, ErrorClassThrottled, ClassifyError(apiError("RequestLimitExceeded")))
+
+	assert.Equal(t, ErrorClassOther, ClassifyError(apiError("InternalError")))
+	assert.Equal(t, ErrorClassOther, ClassifyError(errors.New("connection reset")))
+
+	assert.Equal(t, ErrorClassCanceled, ClassifyError(context.Canceled))
+	assert.Equal(t, ErrorClassTimeout, ClassifyError(&smithy.OperationError{
+		ServiceID:     "RDS",
+		OperationName: "DescribeDBInstances",
+		Err:           fmt.Errorf("request send failed: %w", context.DeadlineExceeded),
+	}))
+}
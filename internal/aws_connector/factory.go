@@ -5,68 +5,215 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/retry"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
 	"github.com/sirupsen/logrus"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 )
 
 type ClientFactory interface {
 	S3Client(ctx context.Context, region *string) (S3Client, error)
 	RDSClient(ctx context.Context, region *string) (RDSClient, error)
+	EC2Client(ctx context.Context, region *string) (EC2Client, error)
+	STSClient(ctx context.Context, region *string) (STSClient, error)
 }
 
+const (
+	serviceS3  = "S3"
+	serviceRDS = "RDS"
+	serviceEC2 = "EC2"
+	serviceSTS = "STS"
+)
+
+// clientKey identifies a memoised client, the empty region stands for the region of the base config
+type clientKey struct {
+	service string
+	region  string
+}
+
+// defaultAwsClientFactory loads the base config once and memoises the clients per service and region,
+// it is safe for concurrent use
 type defaultAwsClientFactory struct {
-	logger *logrus.Logger
+	logger     *logrus.Logger
+	loadConfig func(ctx context.Context, opts ClientFactoryOptions) (aws.Config, error)
+	opts       ClientFactoryOptions
+
+	mu      sync.Mutex
+	base    *aws.Config
+	clients map[clientKey]any
 }
 
 var _ ClientFactory = (*defaultAwsClientFactory)(nil)
 
-func NewClientFactory(logger *logrus.Logger) ClientFactory {
+type ClientFactoryOptions struct {
+	rateLimiter *RateLimiter
+	awsProfile  string
+	assumeRole  *AssumeRole
+}
+
+type ClientFactoryOptionFnc func(*ClientFactoryOptions)
+
+// WithRateLimiter makes the requests of every client wait for the rate limit of their service and region
+func WithRateLimiter(l *RateLimiter) ClientFactoryOptionFnc {
+	return func(o *ClientFactoryOptions) {
+		o.rateLimiter = l
+	}
+}
+
+// WithAWSProfile loads the credentials and the settings of the named profile of the shared AWS config files
+func WithAWSProfile(profile string) ClientFactoryOptionFnc {
+	return func(o *ClientFactoryOptions) {
+		o.awsProfile = profile
+	}
+}
+
+// WithAssumeRole makes the clients assume the role with the loaded credentials
+func WithAssumeRole(r AssumeRole) ClientFactoryOptionFnc {
+	return func(o *ClientFactoryOptions) {
+		o.assumeRole = &r
+	}
+}
+
+func NewClientFactory(logger *logrus.Logger, opts ...ClientFactoryOptionFnc) ClientFactory {
+	return newClientFactory(logger, loadDefaultConfig, opts...)
+}
+
+func newClientFactory(
+	logger *logrus.Logger,
+	loadConfig func(ctx context.Context, opts ClientFactoryOptions) (aws.Config, error),
+	opts ...ClientFactoryOptionFnc,
+) *defaultAwsClientFactory {
+	var options ClientFactoryOptions
+	for _, fn := range opts {
+		fn(&options)
+	}
+
 	return &defaultAwsClientFactory{
-		logger: logger,
+		logger:     logger,
+		loadConfig: loadConfig,
+		opts:       options,
+		clients:    map[clientKey]any{},
 	}
 }
 
 func (f *defaultAwsClientFactory) S3Client(ctx context.Context, region *string) (S3Client, error) {
-	cfg, err := f.loadConfig(ctx, region)
-	log := f.logger.WithField("client", "S3")
-
+	c, err := f.client(ctx, serviceS3, region, func(cfg aws.Config, _ *logrus.Entry) any {
+		return newS3Client(s3.NewFromConfig(cfg))
+	})
 	if err != nil {
-		log.WithError(err).
-			Error("client init failed")
 		return nil, err
 	}
-	log.Debugf("client init successful")
-	return newS3Client(s3.NewFromConfig(cfg)), nil
+	return c.(S3Client), nil
 }
 
 func (f *defaultAwsClientFactory) RDSClient(ctx context.Context, region *string) (RDSClient, error) {
-	cfg, err := f.loadConfig(ctx, region)
-	log := f.logger.WithField("client", "RDS")
+	c, err := f.client(ctx, serviceRDS, region, func(cfg aws.Config, log *logrus.Entry) any {
+		return newRDSClient(rds.NewFromConfig(cfg), log)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.(RDSClient), nil
+}
+
+func (f *defaultAwsClientFactory) EC2Client(ctx context.Context, region *string) (EC2Client, error) {
+	c, err := f.client(ctx, serviceEC2, region, func(cfg aws.Config, _ *logrus.Entry) any {
+		return newEC2Client(ec2.NewFromConfig(cfg))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.(EC2Client), nil
+}
+
+func (f *defaultAwsClientFactory) STSClient(ctx context.Context, region *string) (STSClient, error) {
+	c, err := f.client(ctx, serviceSTS, region, func(cfg aws.Config, _ *logrus.Entry) any {
+		return newSTSClient(sts.NewFromConfig(cfg))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.(STSClient), nil
+}
+
+// client returns the memoised client of the service in the region, it is built from a copy of the
+// base config on the first call
+func (f *defaultAwsClientFactory) client(
+	ctx context.Context,
+	service string,
+	region *string,
+	build func(cfg aws.Config, log *logrus.Entry) any,
+) (any, error) {
+	key := clientKey{service: service}
+	if region != nil {
+		key.region = *region
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if c, exist := f.clients[key]; exist {
+		return c, nil
+	}
 
+	log := f.logger.WithField("client", service)
+	cfg, err := f.baseConfig(ctx)
 	if err != nil {
 		log.WithError(err).
 			Error("client init failed")
 		return nil, err
 	}
-	log.Debugf("client init successful")
-	return newRDSClient(rds.NewFromConfig(cfg)), nil
+	if region != nil {
+		cfg.Region = *region
+	}
+	if l := f.opts.rateLimiter; l != nil {
+		// the copy of the base config shares the options with it
+		cfg.APIOptions = append(slices.Clone(cfg.APIOptions), l.middleware(strings.ToLower(service), cfg.Region))
+	}
+
+	c := build(cfg, log)
+	f.clients[key] = c
+	log.WithField("region", cfg.Region).
+		Debugf("client init successful")
+	return c, nil
+}
+
+// baseConfig loads the config once, a failed load is retried on the next call. The caller holds the lock.
+func (f *defaultAwsClientFactory) baseConfig(ctx context.Context) (aws.Config, error) {
+	if f.base == nil {
+		cfg, err := f.loadConfig(ctx, f.opts)
+		if err != nil {
+			return aws.Config{}, err
+		}
+		f.base = &cfg
+	}
+	return f.base.Copy(), nil
 }
 
-func (f *defaultAwsClientFactory) loadConfig(ctx context.Context, region *string) (aws.Config, error) {
+func loadDefaultConfig(ctx context.Context, opts ClientFactoryOptions) (aws.Config, error) {
 	const MaxAttempts = 10
 	const MaxBackoffDelay = 10 * time.Second
 
-	opts := []func(*config.LoadOptions) error{
+	loadOpts := []func(*config.LoadOptions) error{
 		config.WithRetryer(adaptiveRetryer(MaxAttempts, MaxBackoffDelay)),
 	}
+	if opts.awsProfile != "" {
+		loadOpts = append(loadOpts, config.WithSharedConfigProfile(opts.awsProfile))
+	}
 
-	if region != nil {
-		opts = append(opts, config.WithRegion(*region))
+	cfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
+	if err != nil || opts.assumeRole == nil {
+		return cfg, err
 	}
 
-	return config.LoadDefaultConfig(ctx, opts...)
+	// the credentials of the role are refreshed by the cache before they expire
+	cfg.Credentials = aws.NewCredentialsCache(assumeRoleProvider(sts.NewFromConfig(cfg), *opts.assumeRole))
+	return cfg, nil
 }
 
 func adaptiveRetryer(maxAttempts int, maxBackoffDelay time.Duration) func() aws.Retryer {
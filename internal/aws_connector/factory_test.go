@@ -0,0 +1,133 @@
+package aws_connector
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func testFactory(loads *atomic.Int32, err error) *defaultAwsClientFactory {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	return newClientFactory(logger, func(context.Context, ClientFactoryOptions) (aws.Config, error) {
+		loads.Add(1)
+		if err != nil {
+			return aws.Config{}, err
+		}
+		return aws.Config{Region: "us-east-1"}, nil
+	})
+}
+
+func TestClientPerRegion(t *testing.T) {
+	const buckets = 5000
+	regions := []string{"us-east-1", "eu-west-1", "ap-south-2"}
+
+	var loads atomic.Int32
+	f := testFactory(&loads, nil)
+
+	// every bucket asks for the client of its region at the same time like the tag fetching of a scan
+	clients := make([]S3Client, buckets)
+	var wg sync.WaitGroup
+	for i := 0; i < buckets; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			region := regions[i%len(regions)]
+			c, err := f.S3Client(context.Background(), &region)
+			assert.Nil(t, err)
+			clients[i] = c
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), loads.Load())
+	assert.Len(t, f.clients, len(regions))
+	for i, c := range clients {
+		assert.Same(t, clients[i%len(regions)], c)
+	}
+	assert.NotSame(t, clients[0], clients[1])
+	assert.NotSame(t, clients[1], clients[2])
+}
+
+func TestClientPerService(t *testing.T) {
+	var loads atomic.Int32
+	f := testFactory(&loads, nil)
+	region := "eu-west-1"
+
+	rdsClient, err := f.RDSClient(context.Background(), &region)
+	assert.Nil(t, err)
+	sameRDSClient, err := f.RDSClient(context.Background(), &region)
+	assert.Nil(t, err)
+	assert.Same(t, rdsClient, sameRDSClient)
+
+	_, err = f.EC2Client(context.Background(), &region)
+	assert.Nil(t, err)
+	_, err = f.STSClient(context.Background(), nil)
+	assert.Nil(t, err)
+
+	assert.Equal(t, int32(1), loads.Load())
+	assert.Len(t, f.clients, 3)
+	assert.Contains(t, f.clients, clientKey{service: serviceSTS})
+}
+
+func TestRegionOfClient(t *testing.T) {
+	var loads atomic.Int32
+	f := testFactory(&loads, nil)
+	region := "eu-west-1"
+
+	_, err := f.S3Client(context.Background(), &region)
+	assert.Nil(t, err)
+
+	// the region of a client does not leak into the base config
+	cfg, err := f.baseConfig(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "us-east-1", cfg.Region)
+}
+
+func TestFailedConfigLoad(t *testing.T) {
+	var loads atomic.Int32
+	f := testFactory(&loads, errors.New("no credentials"))
+
+	_, err := f.S3Client(context.Background(), nil)
+	assert.EqualError(t, err, "no credentials")
+	_, err = f.S3Client(context.Background(), nil)
+	assert.EqualError(t, err, "no credentials")
+
+	assert.Equal(t, int32(2), loads.Load())
+	assert.Empty(t, f.clients)
+}
+
+func TestLoadAWSProfile(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "config")
+	assert.Nil(t, os.WriteFile(configFile, []byte(`[default]
+region = us-east-1
+
+[profile inventory]
+region = ap-south-2
+`), 0o600))
+	t.Setenv("AWS_CONFIG_FILE", configFile)
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_PROFILE", "")
+
+	cfg, err := loadDefaultConfig(context.Background(), ClientFactoryOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, "us-east-1", cfg.Region)
+
+	cfg, err = loadDefaultConfig(context.Background(), ClientFactoryOptions{awsProfile: "inventory"})
+	assert.Nil(t, err)
+	assert.Equal(t, "ap-south-2", cfg.Region)
+
+	_, err = loadDefaultConfig(context.Background(), ClientFactoryOptions{awsProfile: "missing"})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,246 @@
+package aws_connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/aws/smithy-go/middleware"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	rateLimitMiddlewareID = "RateLimit"
+	signingMiddlewareID   = "Signing"
+)
+
+// RateLimit is the number of requests per second a service may receive in every region, or in the
+// given region only if the Region is set
+type RateLimit struct {
+	Service   string
+	Region    string
+	PerSecond float64
+}
+
+func (l RateLimit) String() string {
+	if l.Region == "" {
+		return fmt.Sprintf("%s=%g/s", l.Service, l.PerSecond)
+	}
+	return fmt.Sprintf("%s/%s=%g/s", l.Service, l.Region, l.PerSecond)
+}
+
+// RateLimitWait tells how long the requests of a service waited for the rate limit in a region
+type RateLimitWait struct {
+	Service  string
+	Region   string
+	Requests int
+	Delayed  int
+	Total    time.Duration
+	Max      time.Duration
+}
+
+// MarshalJSON writes the wait times as durations e.g.: "1.5s"
+func (w RateLimitWait) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Service   string `json:"service"`
+		Region    string `json:"region"`
+		Requests  int    `json:"requests"`
+		Delayed   int    `json:"delayed"`
+		TotalWait string `json:"totalWait"`
+		MaxWait   string `json:"maxWait"`
+	}{w.Service, w.Region, w.Requests, w.Delayed, w.Total.String(), w.Max.String()})
+}
+
+// RateLimitedServices are the services a rate limit can be set for
+func RateLimitedServices() []string {
+	return []string{
+		strings.ToLower(serviceS3),
+		strings.ToLower(serviceRDS),
+		strings.ToLower(serviceEC2),
+		strings.ToLower(serviceSTS),
+	}
+}
+
+// RateLimiter holds a token bucket per service and region, the requests wait for a token before they
+// are sent, retries included. It is safe for concurrent use.
+type RateLimiter struct {
+	limits []RateLimit
+	now    func() time.Time
+
+	mu      sync.Mutex
+	buckets map[clientKey]*tokenBucket
+	waits   map[clientKey]*RateLimitWait
+}
+
+func NewRateLimiter(limits []RateLimit) *RateLimiter {
+	return &RateLimiter{
+		limits:  append([]RateLimit{}, limits...),
+		now:     time.Now,
+		buckets: map[clientKey]*tokenBucket{},
+		waits:   map[clientKey]*RateLimitWait{},
+	}
+}
+
+// Wait blocks until the request of the service is allowed in the region, it gives up with the context error
+func (l *RateLimiter) Wait(ctx context.Context, service string, region string) error {
+	key := clientKey{service: strings.ToLower(service), region: region}
+	b := l.bucket(key)
+	if b == nil {
+		return nil
+	}
+
+	delay := b.reserve(l.now())
+	if delay > 0 {
+		t := time.NewTimer(delay)
+		defer t.Stop()
+
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			b.cancel()
+			return ctx.Err()
+		}
+	}
+
+	l.record(key, delay)
+	return nil
+}
+
+// Waits returns the time spent waiting per service and region, ordered by service and region
+func (l *RateLimiter) Waits() []RateLimitWait {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var waits []RateLimitWait
+	for _, w := range l.waits {
+		waits = append(waits, *w)
+	}
+	slices.SortFunc(waits, func(a, b RateLimitWait) int {
+		if c := strings.Compare(a.Service, b.Service); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Region, b.Region)
+	})
+	return waits
+}
+
+// bucket returns the token bucket of the service in the region, nil if there is no limit for them
+func (l *RateLimiter) bucket(key clientKey) *tokenBucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if b, exist := l.buckets[key]; exist {
+		return b
+	}
+
+	var b *tokenBucket
+	if limit, exist := l.limitOf(key); exist {
+		b = newTokenBucket(limit.PerSecond, l.now())
+	}
+	l.buckets[key] = b
+	return b
+}
+
+// limitOf finds the limit of the region, or the one of the service
+func (l *RateLimiter) limitOf(key clientKey) (RateLimit, bool) {
+	var found *RateLimit
+	for i, limit := range l.limits {
+		if limit.Service != key.service {
+			continue
+		}
+		if limit.Region == key.region {
+			return limit, true
+		}
+		if limit.Region == "" {
+			found = &l.limits[i]
+		}
+	}
+	if found == nil {
+		return RateLimit{}, false
+	}
+	return *found, true
+}
+
+func (l *RateLimiter) record(key clientKey, delay time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	w, exist := l.waits[key]
+	if !exist {
+		w = &RateLimitWait{Service: key.service, Region: key.region}
+		l.waits[key] = w
+	}
+	w.Requests++
+	if delay > 0 {
+		w.Delayed++
+		w.Total += delay
+		w.Max = max(w.Max, delay)
+	}
+}
+
+// middleware makes the requests of the client wait for the rate limit, it runs after the retry
+// middleware so every attempt takes a token
+// middleware waits in the finalize step after the retries, so every attempt takes a token, and before the
+// signing, so the signature is not older than the wait when the request is sent
+func (l *RateLimiter) middleware(service string, region string) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Finalize.Insert(middleware.FinalizeMiddlewareFunc(rateLimitMiddlewareID,
+			func(ctx context.Context, in middleware.FinalizeInput, next middleware.FinalizeHandler) (
+				middleware.FinalizeOutput, middleware.Metadata, error,
+			) {
+				if err := l.Wait(ctx, service, region); err != nil {
+					return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+				}
+				return next.HandleFinalize(ctx, in)
+			}), signingMiddlewareID, middleware.Before)
+	}
+}
+
+// tokenBucket allows rate requests per second on average and up to burst requests at once
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, now time.Time) *tokenBucket {
+	burst := math.Max(1, math.Floor(rate))
+	return &tokenBucket{
+		rate:   rate,
+		burst:  burst,
+		tokens: burst,
+		last:   now,
+	}
+}
+
+// reserve takes a token and returns how long the caller has to wait for it
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if now.After(b.last) {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+		b.last = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel gives back the token of a request which did not wait until its turn
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
@@ -0,0 +1,169 @@
+package aws_connector
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	start := time.Now()
+	b := newTokenBucket(2, start)
+
+	// the burst of a second is allowed at once, then a request every half a second
+	assert.Equal(t, time.Duration(0), b.reserve(start))
+	assert.Equal(t, time.Duration(0), b.reserve(start))
+	assert.Equal(t, 500*time.Millisecond, b.reserve(start))
+	assert.Equal(t, 1000*time.Millisecond, b.reserve(start))
+
+	b.cancel()
+	assert.Equal(t, 1000*time.Millisecond, b.reserve(start))
+
+	// the tokens refill up to the burst only
+	assert.Equal(t, time.Duration(0), b.reserve(start.Add(10*time.Second)))
+	assert.Equal(t, time.Duration(0), b.reserve(start.Add(10*time.Second)))
+	assert.Equal(t, 500*time.Millisecond, b.reserve(start.Add(10*time.Second)))
+}
+
+func TestRateLimitOfRegion(t *testing.T) {
+	l := NewRateLimiter([]RateLimit{
+		{Service: "s3", PerSecond: 20},
+		{Service: "s3", Region: "eu-west-1", PerSecond: 5},
+	})
+
+	limit, exist := l.limitOf(clientKey{service: "s3", region: "eu-west-1"})
+	assert.True(t, exist)
+	assert.Equal(t, 5.0, limit.PerSecond)
+
+	limit, exist = l.limitOf(clientKey{service: "s3", region: "us-east-1"})
+	assert.True(t, exist)
+	assert.Equal(t, 20.0, limit.PerSecond)
+
+	_, exist = l.limitOf(clientKey{service: "rds", region: "us-east-1"})
+	assert.False(t, exist)
+
+	assert.Equal(t, "s3=20/s", l.limits[0].String())
+	assert.Equal(t, "s3/eu-west-1=5/s", l.limits[1].String())
+}
+
+func TestRateLimitWait(t *testing.T) {
+	l := NewRateLimiter([]RateLimit{{Service: "s3", PerSecond: 1}})
+
+	assert.Nil(t, l.Wait(context.Background(), "S3", "us-east-1"))
+	assert.Nil(t, l.Wait(context.Background(), "rds", "us-east-1"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, l.Wait(ctx, "s3", "us-east-1"))
+
+	// the requests without limits and the canceled ones are not counted
+	assert.Equal(t,
+		[]RateLimitWait{{Service: "s3", Region: "us-east-1", Requests: 1}},
+		l.Waits())
+}
+
+type fakeHTTPClient struct {
+	requests atomic.Int32
+}
+
+func (c *fakeHTTPClient) Do(_ *http.Request) (*http.Response, error) {
+	c.requests.Add(1)
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"text/xml"}},
+		Body: io.NopCloser(strings.NewReader(`<GetCallerIdentityResponse>
+<GetCallerIdentityResult><Account>123456789012</Account></GetCallerIdentityResult>
+</GetCallerIdentityResponse>`)),
+	}, nil
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	httpClient := &fakeHTTPClient{}
+
+	l := NewRateLimiter([]RateLimit{{Service: "sts", PerSecond: 20}})
+	f := newClientFactory(logger, func(context.Context, ClientFactoryOptions) (aws.Config, error) {
+		return aws.Config{
+			Region:      "us-east-1",
+			Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+			HTTPClient:  httpClient,
+		}, nil
+	}, WithRateLimiter(l))
+
+	client, err := f.STSClient(context.Background(), nil)
+	assert.Nil(t, err)
+
+	start := time.Now()
+	for i := 0; i < 25; i++ {
+		id, err := client.CallerIdentity(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, "123456789012", id.Account)
+	}
+
+	// the burst of 20 requests goes at once, the other 5 wait 50ms each
+	assert.True(t, time.Since(start) >= 200*time.Millisecond, "the requests were not rate limited")
+	assert.Equal(t, int32(25), httpClient.requests.Load())
+
+	waits := l.Waits()
+	assert.Len(t, waits, 1)
+	assert.Equal(t, "sts", waits[0].Service)
+	assert.Equal(t, "us-east-1", waits[0].Region)
+	assert.Equal(t, 25, waits[0].Requests)
+	assert.Equal(t, 5, waits[0].Delayed)
+	assert.True(t, waits[0].Max > 0 && waits[0].Max <= 50*time.Millisecond)
+}
+
+func TestRateLimitMiddlewareBeforeSigning(t *testing.T) {
+	l := NewRateLimiter([]RateLimit{{Service: "sts", PerSecond: 20}})
+
+	var finalize []string
+	client := sts.NewFromConfig(aws.Config{
+		Region:      "us-east-1",
+		Credentials: credentials.NewStaticCredentialsProvider("AKID", "SECRET", ""),
+		HTTPClient:  &fakeHTTPClient{},
+	}, func(o *sts.Options) {
+		o.APIOptions = append(o.APIOptions,
+			l.middleware(serviceSTS, "us-east-1"),
+			func(stack *middleware.Stack) error {
+				finalize = stack.Finalize.List()
+				return nil
+			})
+	})
+
+	_, err := client.GetCallerIdentity(context.Background(), &sts.GetCallerIdentityInput{})
+	assert.Nil(t, err)
+
+	limit := slices.Index(finalize, rateLimitMiddlewareID)
+	assert.True(t, limit >= 0, "the rate limit middleware is missing: %v", finalize)
+	assert.Contains(t, finalize, "Retry")
+	assert.Less(t, slices.Index(finalize, "Retry"), limit, finalize)
+	assert.Less(t, limit, slices.Index(finalize, signingMiddlewareID), finalize)
+}
+
+func TestRateLimitWaitJSON(t *testing.T) {
+	b, err := json.Marshal(RateLimitWait{
+		Service:  "s3",
+		Region:   "eu-west-1",
+		Requests: 10,
+		Delayed:  2,
+		Total:    1500 * time.Millisecond,
+		Max:      time.Second,
+	})
+	assert.Nil(t, err)
+	assert.JSONEq(t,
+		`{"service":"s3","region":"eu-west-1","requests":10,"delayed":2,"totalWait":"1.5s","maxWait":"1s"}`,
+		string(b))
+}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"github.com/aws/aws-sdk-go-v2/service/rds"
 	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/sirupsen/logrus"
 	"time"
 )
 
 type ListRDSParams struct {
+	// PageSize is the number of instances requested per page, zero means the service default (100).
+	// The service accepts values between 20 and 100, the other values are moved into this range.
+	PageSize int32
 }
 
 type ListRDSResult struct {
@@ -33,25 +37,51 @@ type RDSClient interface {
 }
 
 type rdsClient struct {
-	client *rds.Client
+	client rds.DescribeDBInstancesAPIClient
+	logger *logrus.Entry
 }
 
 var _ RDSClient = (*rdsClient)(nil)
 
-func newRDSClient(client *rds.Client) RDSClient {
+func newRDSClient(client rds.DescribeDBInstancesAPIClient, logger *logrus.Entry) RDSClient {
 	return &rdsClient{
 		client: client,
+		logger: logger,
 	}
 }
 
-func (c *rdsClient) List(ctx context.Context, _ ListRDSParams) ([]ListRDSResult, error) {
-	desc, err := c.client.DescribeDBInstances(ctx, nil)
-	if err != nil {
-		return nil, err
+func (c *rdsClient) List(ctx context.Context, p ListRDSParams) ([]ListRDSResult, error) {
+	var res []ListRDSResult
+
+	paginator := rds.NewDescribeDBInstancesPaginator(
+		c.client,
+		&rds.DescribeDBInstancesInput{},
+		func(o *rds.DescribeDBInstancesPaginatorOptions) {
+			if p.PageSize > 0 {
+				o.Limit = clampPageSize(p.PageSize, 20, 100)
+			}
+		})
+
+	pages := 0
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		pages++
+		res = append(res, transformDBInstances(page.DBInstances)...)
 	}
 
+	c.logger.WithField("pages", pages).
+		WithField("instances", len(res)).
+		Debug("RDS instances described")
+
+	return res, nil
+}
+
+func transformDBInstances(instances []types.DBInstance) []ListRDSResult {
 	var res []ListRDSResult
-	for _, r := range desc.DBInstances {
+	for _, r := range instances {
 		res = append(res, ListRDSResult{
 			Arn:        safeDeref(r.DBInstanceArn, ""),
 			ID:         safeDeref(r.DBInstanceIdentifier, ""),
@@ -71,7 +101,7 @@ func (c *rdsClient) List(ctx context.Context, _ ListRDSParams) ([]ListRDSResult,
 		})
 	}
 
-	return res, nil
+	return res
 }
 
 func transformTagsList(tags []types.Tag) map[string]*string {
@@ -85,6 +115,10 @@ func transformTagsList(tags []types.Tag) map[string]*string {
 	return res
 }
 
+func clampPageSize(size, lowest, highest int32) int32 {
+	return max(lowest, min(size, highest))
+}
+
 func safeDeref[T any](p *T, def T) T {
 	if p == nil {
 		return def
@@ -0,0 +1,126 @@
+package aws_connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/service/rds"
+	"github.com/aws/aws-sdk-go-v2/service/rds/types"
+	"github.com/aws/smithy-go/ptr"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"testing"
+)
+
+type fakeDescribeDBInstances struct {
+	instances  int
+	failOnPage int
+	maxRecords []*int32
+}
+
+func (f *fakeDescribeDBInstances) DescribeDBInstances(
+	_ context.Context,
+	in *rds.DescribeDBInstancesInput,
+	_ ...func(*rds.Options),
+) (*rds.DescribeDBInstancesOutput, error) {
+	f.maxRecords = append(f.maxRecords, in.MaxRecords)
+	page := len(f.maxRecords)
+	if page == f.failOnPage {
+		return nil, errors.New("throttled")
+	}
+
+	pageSize := 100
+	if in.MaxRecords != nil {
+		pageSize = int(*in.MaxRecords)
+	}
+
+	start := 0
+	if in.Marker != nil {
+		start, _ = strconv.Atoi(*in.Marker)
+	}
+	end := min(start+pageSize, f.instances)
+
+	out := &rds.DescribeDBInstancesOutput{}
+	for i := start; i < end; i++ {
+		out.DBInstances = append(out.DBInstances, types.DBInstance{
+			DBInstanceArn:        ptr.String(fmt.Sprintf("arn:aws:rds:eu-west-1:123456789012:db:db-%d", i)),
+			DBInstanceIdentifier: ptr.String(fmt.Sprintf("db-%d", i)),
+			TagList:              []types.Tag{{Key: ptr.String("index"), Value: ptr.String(strconv.Itoa(i))}},
+		})
+	}
+	if end < f.instances {
+		out.Marker = ptr.String(strconv.Itoa(end))
+	}
+	return out, nil
+}
+
+func TestRDSListAllPages(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+	fake := &fakeDescribeDBInstances{instances: 250}
+
+	c := newRDSClient(fake, logrus.NewEntry(logger))
+	res, err := c.List(context.Background(), ListRDSParams{})
+	assert.Nil(t, err)
+
+	assert.Len(t, res, 250)
+	assert.Equal(t, "db-0", res[0].ID)
+	assert.Equal(t, "arn:aws:rds:eu-west-1:123456789012:db:db-249", res[249].Arn)
+	assert.Equal(t, ptr.String("249"), res[249].Tags["index"])
+	assert.Equal(t, []*int32{nil, nil, nil}, fake.maxRecords)
+
+	assert.Equal(t, 3, hook.LastEntry().Data["pages"])
+	assert.Equal(t, 250, hook.LastEntry().Data["instances"])
+}
+
+func TestRDSListPageSize(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+	fake := &fakeDescribeDBInstances{instances: 45}
+
+	c := newRDSClient(fake, logrus.NewEntry(logger))
+	res, err := c.List(context.Background(), ListRDSParams{PageSize: 20})
+	assert.Nil(t, err)
+
+	assert.Len(t, res, 45)
+	assert.Equal(t, []*int32{ptr.Int32(20), ptr.Int32(20), ptr.Int32(20)}, fake.maxRecords)
+	assert.Equal(t, 3, hook.LastEntry().Data["pages"])
+}
+
+func TestRDSListPageSizeOutOfRange(t *testing.T) {
+	for size, want := range map[int32]int32{5: 20, 500: 100} {
+		fake := &fakeDescribeDBInstances{instances: 10}
+
+		c := newRDSClient(fake, logrus.NewEntry(logrus.New()))
+		_, err := c.List(context.Background(), ListRDSParams{PageSize: size})
+		assert.Nil(t, err)
+		assert.Equal(t, []*int32{ptr.Int32(want)}, fake.maxRecords)
+	}
+}
+
+func TestRDSListEmpty(t *testing.T) {
+	logger, hook := test.NewNullLogger()
+	logger.SetLevel(logrus.DebugLevel)
+	fake := &fakeDescribeDBInstances{}
+
+	c := newRDSClient(fake, logrus.NewEntry(logger))
+	res, err := c.List(context.Background(), ListRDSParams{})
+	assert.Nil(t, err)
+
+	assert.Empty(t, res)
+	assert.Equal(t, 1, hook.LastEntry().Data["pages"])
+}
+
+func TestRDSListPageError(t *testing.T) {
+	logger, _ := test.NewNullLogger()
+	fake := &fakeDescribeDBInstances{instances: 250, failOnPage: 2}
+
+	c := newRDSClient(fake, logrus.NewEntry(logger))
+	res, err := c.List(context.Background(), ListRDSParams{})
+
+	assert.ErrorContains(t, err, "throttled")
+	assert.Nil(t, res)
+	assert.Len(t, fake.maxRecords, 2)
+}
@@ -3,10 +3,16 @@ package aws_connector
 import (
 	"context"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/smithy-go/ptr"
 	"time"
 )
 
+const (
+	regionUsEast1 = "us-east-1"
+	regionEuWest1 = "eu-west-1"
+)
+
 type ListS3Params struct {
 }
 
@@ -20,7 +26,10 @@ type GetS3RegionParams struct {
 }
 
 type GetS3RegionResult struct {
-	Name   string
+	Name string
+	// LocationConstraint is the raw value returned by GetBucketLocation
+	LocationConstraint string
+	// Region is the region code of the bucket with the legacy location constraints resolved
 	Region string
 }
 
@@ -28,6 +37,10 @@ func NewGetS3RegionParams(name string) GetS3RegionParams {
 	return GetS3RegionParams{name: name}
 }
 
+func (p GetS3RegionParams) BucketName() string {
+	return p.name
+}
+
 type GetS3BucketTagsParams struct {
 	name string
 }
@@ -41,19 +54,29 @@ func NewGetS3BucketTagsParams(name string) GetS3BucketTagsParams {
 	return GetS3BucketTagsParams{name: name}
 }
 
+func (p GetS3BucketTagsParams) BucketName() string {
+	return p.name
+}
+
 type S3Client interface {
 	List(ctx context.Context, p ListS3Params) ([]ListS3Result, error)
 	GetRegion(ctx context.Context, p GetS3RegionParams) (GetS3RegionResult, error)
 	GetTags(ctx context.Context, p GetS3BucketTagsParams) (GetS3BucketTagsResult, error)
 }
 
+type s3API interface {
+	ListBuckets(ctx context.Context, in *s3.ListBucketsInput, optFns ...func(*s3.Options)) (*s3.ListBucketsOutput, error)
+	GetBucketLocation(ctx context.Context, in *s3.GetBucketLocationInput, optFns ...func(*s3.Options)) (*s3.GetBucketLocationOutput, error)
+	GetBucketTagging(ctx context.Context, in *s3.GetBucketTaggingInput, optFns ...func(*s3.Options)) (*s3.GetBucketTaggingOutput, error)
+}
+
 type s3Client struct {
-	client *s3.Client
+	client s3API
 }
 
 var _ S3Client = (*s3Client)(nil)
 
-func newS3Client(client *s3.Client) S3Client {
+func newS3Client(client s3API) S3Client {
 	return &s3Client{
 		client: client,
 	}
@@ -83,22 +106,40 @@ func (c *s3Client) GetRegion(ctx context.Context, p GetS3RegionParams) (GetS3Reg
 	}
 
 	return GetS3RegionResult{
-		Name:   p.name,
-		Region: string(loc.LocationConstraint),
+		Name:               p.name,
+		LocationConstraint: string(loc.LocationConstraint),
+		Region:             normalizeLocationConstraint(loc.LocationConstraint),
 	}, nil
 }
 
-func (c *s3Client) GetTags(ctx context.Context, p GetS3BucketTagsParams) (GetS3BucketTagsResult, error) {
-	tags, err := c.client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{Bucket: ptr.String(p.name)})
-	if err != nil {
-		return GetS3BucketTagsResult{}, err
+// normalizeLocationConstraint resolves the legacy location constraints, buckets in us-east-1 have
+// no location constraint and the ones created in eu-west-1 a long time ago have "EU"
+func normalizeLocationConstraint(loc types.BucketLocationConstraint) string {
+	switch loc {
+	case "":
+		return regionUsEast1
+	case types.BucketLocationConstraintEu:
+		return regionEuWest1
+	default:
+		return string(loc)
 	}
+}
 
+func (c *s3Client) GetTags(ctx context.Context, p GetS3BucketTagsParams) (GetS3BucketTagsResult, error) {
 	res := GetS3BucketTagsResult{
 		Name: p.name,
 		Tags: map[string]*string{},
 	}
 
+	tags, err := c.client.GetBucketTagging(ctx, &s3.GetBucketTaggingInput{Bucket: ptr.String(p.name)})
+	if err != nil {
+		// untagged buckets have no tag set at all
+		if hasErrorCode(err, errorCodeNoSuchTagSet) {
+			return res, nil
+		}
+		return GetS3BucketTagsResult{}, err
+	}
+
 	for _, t := range tags.TagSet {
 		if t.Key == nil {
 			continue
@@ -0,0 +1,91 @@
+package aws_connector
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type fakeS3API struct {
+	s3API
+	locations map[string]types.BucketLocationConstraint
+	tags      map[string][]types.Tag
+	tagErrors map[string]error
+}
+
+func (f *fakeS3API) GetBucketLocation(
+	_ context.Context,
+	in *s3.GetBucketLocationInput,
+	_ ...func(*s3.Options),
+) (*s3.GetBucketLocationOutput, error) {
+	return &s3.GetBucketLocationOutput{LocationConstraint: f.locations[*in.Bucket]}, nil
+}
+
+func (f *fakeS3API) GetBucketTagging(
+	_ context.Context,
+	in *s3.GetBucketTaggingInput,
+	_ ...func(*s3.Options),
+) (*s3.GetBucketTaggingOutput, error) {
+	if err, exist := f.tagErrors[*in.Bucket]; exist {
+		return nil, err
+	}
+	return &s3.GetBucketTaggingOutput{TagSet: f.tags[*in.Bucket]}, nil
+}
+
+func TestNormalizeLocationConstraint(t *testing.T) {
+	assert.Equal(t, "us-east-1", normalizeLocationConstraint(""))
+	assert.Equal(t, "eu-west-1", normalizeLocationConstraint("EU"))
+	assert.Equal(t, "eu-west-1", normalizeLocationConstraint("eu-west-1"))
+	assert.Equal(t, "ap-south-2", normalizeLocationConstraint("ap-south-2"))
+}
+
+func TestGetRegion(t *testing.T) {
+	c := newS3Client(&fakeS3API{
+		locations: map[string]types.BucketLocationConstraint{
+			"legacy":  "EU",
+			"virgina": "",
+			"ohio":    "us-east-2",
+		},
+	})
+
+	for _, tc := range []GetS3RegionResult{
+		{Name: "legacy", LocationConstraint: "EU", Region: "eu-west-1"},
+		{Name: "virgina", LocationConstraint: "", Region: "us-east-1"},
+		{Name: "ohio", LocationConstraint: "us-east-2", Region: "us-east-2"},
+	} {
+		res, err := c.GetRegion(context.Background(), NewGetS3RegionParams(tc.Name))
+		assert.Nil(t, err)
+		assert.Equal(t, tc, res)
+	}
+}
+
+func TestGetTags(t *testing.T) {
+	c := newS3Client(&fakeS3API{
+		tags: map[string][]types.Tag{
+			"tagged": {{Key: ptr.String("Owner"), Value: ptr.String("finance")}},
+		},
+		tagErrors: map[string]error{
+			"untagged": &smithy.GenericAPIError{Code: "NoSuchTagSet"},
+			"denied":   &smithy.GenericAPIError{Code: "AccessDenied"},
+		},
+	})
+
+	res, err := c.GetTags(context.Background(), NewGetS3BucketTagsParams("tagged"))
+	assert.Nil(t, err)
+	assert.Equal(t,
+		GetS3BucketTagsResult{Name: "tagged", Tags: map[string]*string{"Owner": ptr.String("finance")}},
+		res)
+
+	res, err = c.GetTags(context.Background(), NewGetS3BucketTagsParams("untagged"))
+	assert.Nil(t, err)
+	assert.Equal(t,
+		GetS3BucketTagsResult{Name: "untagged", Tags: map[string]*string{}},
+		res)
+
+	_, err = c.GetTags(context.Background(), NewGetS3BucketTagsParams("denied"))
+	assert.Equal(t, ErrorClassAccessDenied, ClassifyError(err))
+}
@@ -0,0 +1,45 @@
+package aws_connector
+
+import (
+	"context"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+type CallerIdentityResult struct {
+	Account string
+	Arn     string
+	UserID  string
+}
+
+type STSClient interface {
+	CallerIdentity(ctx context.Context) (CallerIdentityResult, error)
+}
+
+type stsAPI interface {
+	GetCallerIdentity(ctx context.Context, in *sts.GetCallerIdentityInput, optFns ...func(*sts.Options)) (*sts.GetCallerIdentityOutput, error)
+}
+
+type stsClient struct {
+	client stsAPI
+}
+
+var _ STSClient = (*stsClient)(nil)
+
+func newSTSClient(client stsAPI) STSClient {
+	return &stsClient{
+		client: client,
+	}
+}
+
+func (c *stsClient) CallerIdentity(ctx context.Context) (CallerIdentityResult, error) {
+	id, err := c.client.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return CallerIdentityResult{}, err
+	}
+
+	return CallerIdentityResult{
+		Account: safeDeref(id.Account, ""),
+		Arn:     safeDeref(id.Arn, ""),
+		UserID:  safeDeref(id.UserId, ""),
+	}, nil
+}
@@ -0,0 +1,47 @@
+package aws_connector
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type fakeSTSAPI struct {
+	out *sts.GetCallerIdentityOutput
+	err error
+}
+
+func (f *fakeSTSAPI) GetCallerIdentity(
+	_ context.Context,
+	_ *sts.GetCallerIdentityInput,
+	_ ...func(*sts.Options),
+) (*sts.GetCallerIdentityOutput, error) {
+	return f.out, f.err
+}
+
+func TestCallerIdentity(t *testing.T) {
+	c := newSTSClient(&fakeSTSAPI{
+		out: &sts.GetCallerIdentityOutput{
+			Account: ptr.String("123456789012"),
+			Arn:     ptr.String("arn:aws:iam::123456789012:user/scanner"),
+			UserId:  ptr.String("AIDAEXAMPLE"),
+		},
+	})
+
+	id, err := c.CallerIdentity(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t,
+		CallerIdentityResult{
+			Account: "123456789012",
+			Arn:     "arn:aws:iam::123456789012:user/scanner",
+			UserID:  "AIDAEXAMPLE",
+		},
+		id)
+
+	_, err = newSTSClient(&fakeSTSAPI{err: errors.New("expired token")}).
+		CallerIdentity(context.Background())
+	assert.ErrorContains(t, err, "expired token")
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vcsomor/aws-resources/config"
+	"github.com/vcsomor/aws-resources/internal/diff"
+	"github.com/vcsomor/aws-resources/internal/lister/writer/stdout"
+	"github.com/vcsomor/aws-resources/log"
+)
+
+// DiffScans is the command entry point
+func DiffScans(_ *cobra.Command, args []string) {
+	logger := log.NewLogger(config.Config())
+
+	oldScan, err := diff.LoadScan(args[0])
+	if err != nil {
+		logger.WithError(err).
+			WithField("scan", args[0]).
+			Error("unable to load the old scan")
+		return
+	}
+
+	newScan, err := diff.LoadScan(args[1])
+	if err != nil {
+		logger.WithError(err).
+			WithField("scan", args[1]).
+			Error("unable to load the new scan")
+		return
+	}
+
+	res := diff.Compare(oldScan, newScan)
+	logger.Debugf("added: %d, removed: %d, changed: %d", len(res.Added), len(res.Removed), len(res.Changed))
+
+	w, err := stdout.NewWriter(stdout.WithIndentation("\t"))
+	if err != nil {
+		return
+	}
+	_ = w.Write(res)
+}
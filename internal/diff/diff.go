@@ -0,0 +1,121 @@
+package diff
+
+import (
+	"github.com/vcsomor/aws-resources/internal/lister"
+	"reflect"
+	"slices"
+)
+
+type ResourceRef struct {
+	Arn string `json:"arn"`
+	ID  any    `json:"id"`
+}
+
+type FieldChange struct {
+	Path string `json:"path"`
+	Old  any    `json:"old"`
+	New  any    `json:"new"`
+}
+
+type ResourceChange struct {
+	ResourceRef
+	Changes []FieldChange `json:"changes"`
+}
+
+type Result struct {
+	Added   []ResourceRef    `json:"added"`
+	Removed []ResourceRef    `json:"removed"`
+	Changed []ResourceChange `json:"changed"`
+}
+
+// Compare matches the resources of two scans by ARN and reports the differences
+// on the flattened paths of the resources
+func Compare(oldScan, newScan []Resource) Result {
+	flattener := lister.NewObjectFlattener()
+	oldByArn := byArn(oldScan)
+	newByArn := byArn(newScan)
+
+	res := Result{
+		Added:   []ResourceRef{},
+		Removed: []ResourceRef{},
+		Changed: []ResourceChange{},
+	}
+
+	for _, arn := range sortedArns(newByArn) {
+		if _, exist := oldByArn[arn]; !exist {
+			res.Added = append(res.Added, refOf(newByArn[arn]))
+		}
+	}
+
+	for _, arn := range sortedArns(oldByArn) {
+		oldRes := oldByArn[arn]
+		newRes, exist := newByArn[arn]
+		if !exist {
+			res.Removed = append(res.Removed, refOf(oldRes))
+			continue
+		}
+
+		changes := compareFields(flattener.Flatten(oldRes), flattener.Flatten(newRes))
+		if len(changes) > 0 {
+			res.Changed = append(res.Changed, ResourceChange{
+				ResourceRef: refOf(newRes),
+				Changes:     changes,
+			})
+		}
+	}
+
+	return res
+}
+
+func compareFields(oldFields, newFields map[string]any) []FieldChange {
+	var paths []string
+	for p := range oldFields {
+		paths = append(paths, p)
+	}
+	for p := range newFields {
+		if _, exist := oldFields[p]; !exist {
+			paths = append(paths, p)
+		}
+	}
+	slices.Sort(paths)
+
+	var changes []FieldChange
+	for _, p := range paths {
+		o, n := oldFields[p], newFields[p]
+		if reflect.DeepEqual(o, n) {
+			continue
+		}
+		changes = append(changes, FieldChange{
+			Path: p,
+			Old:  o,
+			New:  n,
+		})
+	}
+	return changes
+}
+
+func byArn(resources []Resource) map[string]Resource {
+	m := map[string]Resource{}
+	for _, r := range resources {
+		if arn := r.Arn(); arn != "" {
+			m[arn] = r
+		}
+	}
+	return m
+}
+
+func sortedArns(m map[string]Resource) []string {
+	var arns []string
+	for arn := range m {
+		arns = append(arns, arn)
+	}
+	slices.Sort(arns)
+	return arns
+}
+
+func refOf(r Resource) ResourceRef {
+	return ResourceRef{
+		Arn: r.Arn(),
+		ID:  r["id"],
+	}
+}
@@ -0,0 +1,64 @@
+package diff
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	oldScan := []Resource{
+		{
+			"arn": "arn:aws:s3:::kept",
+			"id":  "kept",
+			"properties": map[string]any{
+				"region": "eu-west-1",
+				"tags": map[string]any{
+					"Owner": "finance",
+					"Team":  "platform",
+				},
+			},
+		},
+		{"arn": "arn:aws:s3:::unchanged", "id": "unchanged"},
+		{"arn": "arn:aws:s3:::removed", "id": "removed"},
+	}
+
+	newScan := []Resource{
+		{"arn": "arn:aws:s3:::added", "id": "added"},
+		{"arn": "arn:aws:s3:::unchanged", "id": "unchanged"},
+		{
+			"arn": "arn:aws:s3:::kept",
+			"id":  "kept",
+			"properties": map[string]any{
+				"region": "eu-west-1",
+				"tags": map[string]any{
+					"Owner": "security",
+					"Env":   "prod",
+				},
+			},
+		},
+	}
+
+	assert.Equal(t,
+		Result{
+			Added:   []ResourceRef{{Arn: "arn:aws:s3:::added", ID: "added"}},
+			Removed: []ResourceRef{{Arn: "arn:aws:s3:::removed", ID: "removed"}},
+			Changed: []ResourceChange{
+				{
+					ResourceRef: ResourceRef{Arn: "arn:aws:s3:::kept", ID: "kept"},
+					Changes: []FieldChange{
+						{Path: "properties.tags.Env", Old: nil, New: "prod"},
+						{Path: "properties.tags.Owner", Old: "finance", New: "security"},
+						{Path: "properties.tags.Team", Old: "platform", New: nil},
+					},
+				},
+			},
+		},
+		Compare(oldScan, newScan))
+}
+
+func TestCompareEmpty(t *testing.T) {
+	b, err := json.Marshal(Compare(nil, nil))
+	assert.Nil(t, err)
+	assert.Equal(t, `{"added":[],"removed":[],"changed":[]}`, string(b))
+}
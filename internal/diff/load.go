@@ -0,0 +1,171 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	goyaml "gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+const (
+	arnKey          = "arn"
+	resourcesKey    = "resources"
+	resourceTypeKey = "resourceType"
+
+	jsonExt   = ".json"
+	ndjsonExt = ".ndjson"
+	yamlExt   = ".yaml"
+)
+
+// files written next to the resources by the file output
+var nonResourceFiles = []string{"errors.json", "manifest.json"}
+
+// Resource is a listed resource decoded into generic JSON values
+type Resource map[string]any
+
+func (r Resource) Arn() string {
+	arn, _ := r[arnKey].(string)
+	return arn
+}
+
+// LoadScan reads the resources of a scan, the path is either the target directory of the file output
+// or a file holding the stdout, the yaml or the ndjson output
+func LoadScan(path string) ([]Resource, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.IsDir() {
+		return loadDirectory(path)
+	}
+	return loadFile(path)
+}
+
+func loadDirectory(dir string) ([]Resource, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var resources []Resource
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || slices.Contains(nonResourceFiles, name) {
+			continue
+		}
+
+		if filepath.Ext(name) == ndjsonExt {
+			lines, err := loadLines(filepath.Join(dir, name))
+			if err != nil {
+				return nil, err
+			}
+			resources = append(resources, lines...)
+			continue
+		}
+
+		if ext := filepath.Ext(name); ext != jsonExt && ext != yamlExt {
+			continue
+		}
+
+		var r Resource
+		if err = decodeFile(filepath.Join(dir, name), &r); err != nil {
+			return nil, err
+		}
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+func loadFile(file string) ([]Resource, error) {
+	if filepath.Ext(file) == ndjsonExt {
+		return loadLines(file)
+	}
+
+	var doc any
+	if err := decodeFile(file, &doc); err != nil {
+		return nil, err
+	}
+
+	switch v := doc.(type) {
+	case []any:
+		return asResources(file, v)
+	case map[string]any:
+		if list, isEnvelope := v[resourcesKey].([]any); isEnvelope {
+			return asResources(file, list)
+		}
+		if _, isResource := v[arnKey]; isResource {
+			return []Resource{v}, nil
+		}
+	}
+	return nil, fmt.Errorf("unrecognized scan format in %s", file)
+}
+
+// loadLines reads a resource per line. Only the lines carry the resourceType, the type of the collector
+// which listed the resource, the other formats don't write it. It is dropped so a resource compares the
+// same whatever format the scans were written in.
+func loadLines(file string) ([]Resource, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var resources []Resource
+	for dec.More() {
+		var r Resource
+		if err = dec.Decode(&r); err != nil {
+			return nil, fmt.Errorf("unable to decode %s: %w", file, err)
+		}
+		delete(r, resourceTypeKey)
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+func asResources(file string, list []any) ([]Resource, error) {
+	var resources []Resource
+	for _, item := range list {
+		r, ok := item.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unrecognized resource in %s", file)
+		}
+		resources = append(resources, r)
+	}
+	return resources, nil
+}
+
+// decodeFile decodes a JSON or a YAML file into the JSON values, so the resources compare the same
+// whatever format they were written in
+func decodeFile(file string, v any) error {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+
+	if filepath.Ext(file) == yamlExt {
+		if b, err = yamlToJSON(b); err != nil {
+			return fmt.Errorf("unable to decode %s: %w", file, err)
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err = dec.Decode(v); err != nil {
+		return fmt.Errorf("unable to decode %s: %w", file, err)
+	}
+	return nil
+}
+
+func yamlToJSON(b []byte) ([]byte, error) {
+	var doc any
+	if err := goyaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
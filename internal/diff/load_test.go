@@ -0,0 +1,133 @@
+package diff
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadDirectory(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "arn_aws_s3___bucket.json"), `{"arn": "arn:aws:s3:::bucket", "id": "bucket"}`)
+	writeFile(t, filepath.Join(root, "errors.json"), `{"errors": []}`)
+	writeFile(t, filepath.Join(root, "manifest.json"), `{"account": "123456789012"}`)
+	writeFile(t, filepath.Join(root, "s3.csv"), "arn\n")
+
+	res, err := LoadScan(root)
+	assert.Nil(t, err)
+	assert.Equal(t, []Resource{{"arn": "arn:aws:s3:::bucket", "id": "bucket"}}, res)
+}
+
+func TestLoadYAMLDirectory(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "arn_aws_s3___bucket.yaml"), `arn: arn:aws:s3:::bucket
+id: bucket
+creationTime: "2024-03-01T10:00:00Z"
+properties:
+    size: 10
+    tags:
+        Owner: "true"
+        Empty: null
+`)
+	writeFile(t, filepath.Join(root, "errors.json"), `{"errors": []}`)
+	writeFile(t, filepath.Join(root, "manifest.json"), `{"account": "123456789012"}`)
+
+	res, err := LoadScan(root)
+	assert.Nil(t, err)
+	assert.Equal(t,
+		[]Resource{{
+			"arn":          "arn:aws:s3:::bucket",
+			"id":           "bucket",
+			"creationTime": "2024-03-01T10:00:00Z",
+			"properties": map[string]any{
+				"size": json.Number("10"),
+				"tags": map[string]any{"Owner": "true", "Empty": nil},
+			},
+		}},
+		res)
+}
+
+func TestLoadYAMLStdoutFile(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "scan.yaml"), `resources:
+  - arn: arn:aws:s3:::bucket
+    id: bucket
+errors: []
+manifest:
+  account: "123456789012"
+`)
+
+	res, err := LoadScan(filepath.Join(root, "scan.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, []Resource{{"arn": "arn:aws:s3:::bucket", "id": "bucket"}}, res)
+}
+
+func TestLoadStdoutFile(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "scan.json"), `{
+	"manifest": {"account": "123456789012"},
+	"resources": [{"arn": "arn:aws:s3:::bucket", "id": "bucket", "properties": {"size": 10}}],
+	"errors": []
+}`)
+
+	res, err := LoadScan(filepath.Join(root, "scan.json"))
+	assert.Nil(t, err)
+	assert.Equal(t,
+		[]Resource{{
+			"arn":        "arn:aws:s3:::bucket",
+			"id":         "bucket",
+			"properties": map[string]any{"size": json.Number("10")},
+		}},
+		res)
+}
+
+func TestLoadNDJSON(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "resources.ndjson"),
+		`{"resourceType":"s3","arn":"arn:aws:s3:::bucket-1","id":"bucket-1"}`+"\n"+
+			`{"resourceType":"s3","arn":"arn:aws:s3:::bucket-2","id":"bucket-2"}`+"\n")
+	writeFile(t, filepath.Join(root, "errors.json"), `{"errors": []}`)
+
+	expected := []Resource{
+		{"arn": "arn:aws:s3:::bucket-1", "id": "bucket-1"},
+		{"arn": "arn:aws:s3:::bucket-2", "id": "bucket-2"},
+	}
+
+	res, err := LoadScan(root)
+	assert.Nil(t, err)
+	assert.Equal(t, expected, res)
+
+	res, err = LoadScan(filepath.Join(root, "resources.ndjson"))
+	assert.Nil(t, err)
+	assert.Equal(t, expected, res)
+}
+
+func TestLoadArrayFile(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "scan.json"), `[{"arn": "arn:aws:s3:::bucket"}]`)
+
+	res, err := LoadScan(filepath.Join(root, "scan.json"))
+	assert.Nil(t, err)
+	assert.Equal(t, []Resource{{"arn": "arn:aws:s3:::bucket"}}, res)
+}
+
+func TestLoadErrors(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "other.json"), `{"something": "else"}`)
+	writeFile(t, filepath.Join(root, "broken.json"), `{`)
+
+	_, err := LoadScan(filepath.Join(root, "other.json"))
+	assert.ErrorContains(t, err, "unrecognized scan format")
+
+	_, err = LoadScan(filepath.Join(root, "broken.json"))
+	assert.ErrorContains(t, err, "unable to decode")
+
+	_, err = LoadScan(filepath.Join(root, "missing.json"))
+	assert.NotNil(t, err)
+}
+
+func writeFile(t *testing.T, f string, content string) {
+	assert.Nil(t, os.WriteFile(f, []byte(content), 0o644))
+}
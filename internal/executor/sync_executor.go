@@ -1,71 +1,213 @@
 package executor
 
-type SynchronousResult struct {
-	Outcome any
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type SynchronousResult[T any] struct {
+	Outcome T
 	Error   error
 }
 
+// CompletedResult is the result of the task at Index of the submitted tasks
+type CompletedResult[T any] struct {
+	SynchronousResult[T]
+	Index int
+}
+
+// SynchronousExecutor runs tasks on a threadpool, the tasks are run with Execute, ExecuteAll
+// and ExecuteAllAsCompleted
 type SynchronousExecutor interface {
-	Execute(task Task) (any, error)
-	ExecuteAll(tasks []Task) []SynchronousResult
+	pool() Threadpool
+	options() ExecutorOptions
 }
 
-type syncExecutionData struct {
-	future  Future
-	outcome any
-	err     error
+type ExecutorOptions struct {
+	taskTimeout time.Duration
+}
+
+type ExecutorOptionFnc func(*ExecutorOptions)
+
+// WithTaskTimeout sets a deadline for every single task, zero means no deadline
+func WithTaskTimeout(timeout time.Duration) ExecutorOptionFnc {
+	return func(o *ExecutorOptions) {
+		o.taskTimeout = timeout
+	}
 }
 
 type syncExecutor struct {
-	p Threadpool
+	p    Threadpool
+	opts ExecutorOptions
 }
 
-func NewSynchronousExecutor(p Threadpool) SynchronousExecutor {
+func NewSynchronousExecutor(p Threadpool, opts ...ExecutorOptionFnc) SynchronousExecutor {
+	var options ExecutorOptions
+	for _, fn := range opts {
+		fn(&options)
+	}
+
 	return &syncExecutor{
-		p: p,
+		p:    p,
+		opts: options,
 	}
 }
 
 var _ SynchronousExecutor = (*syncExecutor)(nil)
 
-func (e *syncExecutor) Execute(task Task) (any, error) {
-	f, err := e.p.SubmitTask(task)
+func (e *syncExecutor) pool() Threadpool {
+	return e.p
+}
+
+func (e *syncExecutor) options() ExecutorOptions {
+	return e.opts
+}
+
+// Execute runs a single task and waits for it
+func Execute[T any](ctx context.Context, e SynchronousExecutor, task Task[T]) (T, error) {
+	if err := ctx.Err(); err != nil {
+		var zero T
+		return zero, err
+	}
+
+	f, err := SubmitTask(ctx, e.pool(), withTimeout(task, e.options()))
 	if err != nil {
-		return nil, err
+		var zero T
+		return zero, err
 	}
-	return f.GetWait(), nil
+	return f.WaitContext(ctx)
 }
 
-func (e *syncExecutor) ExecuteAll(tasks []Task) []SynchronousResult {
-	var r []*syncExecutionData
+// ExecuteAll runs every task and waits for them, the results are in the order of the tasks. Once the
+// context is done the tasks not yet submitted or not yet finished get the context error as their result
+func ExecuteAll[T any](ctx context.Context, e SynchronousExecutor, tasks []Task[T]) []SynchronousResult[T] {
+	var results []SynchronousResult[T]
+	if len(tasks) > 0 {
+		results = make([]SynchronousResult[T], len(tasks))
+	}
 
-	for _, t := range tasks {
-		f, err := e.p.SubmitTask(t)
-		if err != nil {
-			r = append(r, &syncExecutionData{err: err})
-			continue
-		}
-		r = append(r, &syncExecutionData{future: f})
+	for r := range ExecuteAllAsCompleted(ctx, e, tasks) {
+		results[r.Index] = r.SynchronousResult
 	}
+	return results
+}
 
-	for _, d := range r {
-		currentDt := d
-		f := currentDt.future
-		if d.err != nil || f == nil {
-			continue
+// ExecuteAllAsCompleted runs every task and delivers their results in the order they complete, the
+// channel is closed once every task has a result. Cancellation works the same way as in ExecuteAll.
+func ExecuteAllAsCompleted[T any](ctx context.Context, e SynchronousExecutor, tasks []Task[T]) <-chan CompletedResult[T] {
+	c := newCompletion[T](len(tasks))
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.cancel(ctx.Err())
+		case <-c.finished:
+		}
+	}()
+
+	go func() {
+		for i, t := range tasks {
+			if err := ctx.Err(); err != nil {
+				c.deliver(i, SynchronousResult[T]{Error: err})
+				continue
+			}
+
+			index, task := i, withTimeout(t, e.options())
+			err := e.pool().Submit(func() {
+				outcome, err := runTask(ctx, task)
+				c.deliver(index, SynchronousResult[T]{Outcome: outcome, Error: err})
+			})
+			if err != nil {
+				c.deliver(i, SynchronousResult[T]{Error: err})
+			}
 		}
+	}()
+
+	return c.results
+}
 
-		d.outcome = f.GetWait()
+func withTimeout[T any](t Task[T], opts ExecutorOptions) Task[T] {
+	if opts.taskTimeout <= 0 {
+		return t
 	}
-	return transformSyncResult(r)
+	return &timeoutTask[T]{
+		task:    t,
+		timeout: opts.taskTimeout,
+	}
+}
+
+type timeoutTask[T any] struct {
+	task    Task[T]
+	timeout time.Duration
 }
 
-func transformSyncResult(r []*syncExecutionData) (results []SynchronousResult) {
-	for _, dt := range r {
-		results = append(results, SynchronousResult{
-			Outcome: dt.outcome,
-			Error:   dt.err,
-		})
+func (t *timeoutTask[T]) Execute(ctx context.Context) (T, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.task.Execute(ctx)
+}
+
+// completion hands out exactly one result per task, whichever comes first: the outcome of the task
+// or the cancellation of the context
+type completion[T any] struct {
+	mu        sync.Mutex
+	delivered []bool
+	remaining int
+
+	results  chan CompletedResult[T]
+	finished chan struct{}
+}
+
+func newCompletion[T any](tasks int) *completion[T] {
+	c := &completion[T]{
+		delivered: make([]bool, tasks),
+		remaining: tasks,
+		results:   make(chan CompletedResult[T], tasks),
+		finished:  make(chan struct{}),
 	}
-	return results
+	if tasks == 0 {
+		c.finish()
+	}
+	return c
+}
+
+func (c *completion[T]) deliver(index int, r SynchronousResult[T]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.delivered[index] {
+		return
+	}
+	c.delivered[index] = true
+	c.remaining--
+
+	// the channel has room for every task, sending never blocks
+	c.results <- CompletedResult[T]{
+		SynchronousResult: r,
+		Index:             index,
+	}
+	if c.remaining == 0 {
+		c.finish()
+	}
+}
+
+func (c *completion[T]) cancel(err error) {
+	c.mu.Lock()
+	var pending []int
+	for i, d := range c.delivered {
+		if !d {
+			pending = append(pending, i)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, i := range pending {
+		c.deliver(i, SynchronousResult[T]{Error: err})
+	}
+}
+
+func (c *completion[T]) finish() {
+	close(c.results)
+	close(c.finished)
 }
@@ -0,0 +1,85 @@
+//go:build unix
+
+package executor
+
+import (
+	"context"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// the benchmarks simulate an S3 scan: thousands of short GetBucketLocation/GetBucketTagging
+// calls in flight, the tasks mostly wait on the network so the CPU time is the cost of waiting
+const (
+	benchmarkThreads  = 1000
+	benchmarkTasks    = 5000
+	benchmarkTaskWait = 20 * time.Millisecond
+)
+
+// pollingWait is how the futures used to be waited for, kept as a baseline
+func pollingWait[T any](f Future[T]) (T, error) {
+	for !f.IsDone() {
+		time.Sleep(1 * time.Millisecond)
+	}
+	return f.Get()
+}
+
+func BenchmarkExecuteAll(b *testing.B) {
+	benchmarkCPU(b, func(p Threadpool, tasks []Task[string]) {
+		ExecuteAll(context.Background(), NewSynchronousExecutor(p), tasks)
+	})
+}
+
+func BenchmarkExecuteAllAsCompleted(b *testing.B) {
+	benchmarkCPU(b, func(p Threadpool, tasks []Task[string]) {
+		for range ExecuteAllAsCompleted(context.Background(), NewSynchronousExecutor(p), tasks) {
+		}
+	})
+}
+
+func BenchmarkPollingWait(b *testing.B) {
+	benchmarkCPU(b, func(p Threadpool, tasks []Task[string]) {
+		var futures []Future[string]
+		for _, t := range tasks {
+			f, err := SubmitTask(context.Background(), p, t)
+			if err != nil {
+				b.Fatal(err)
+			}
+			futures = append(futures, f)
+		}
+		for _, f := range futures {
+			_, _ = pollingWait(f)
+		}
+	})
+}
+
+func benchmarkCPU(b *testing.B, run func(p Threadpool, tasks []Task[string])) {
+	p, err := NewThreadpool(benchmarkThreads)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer p.Shutdown()
+
+	tasks := make([]Task[string], benchmarkTasks)
+	for i := range tasks {
+		tasks[i] = &testTask{sleep: benchmarkTaskWait}
+	}
+
+	b.ResetTimer()
+	start := cpuTime(b)
+	for i := 0; i < b.N; i++ {
+		run(p, tasks)
+	}
+	b.StopTimer()
+
+	b.ReportMetric(float64((cpuTime(b)-start).Milliseconds())/float64(b.N), "cpu-ms/op")
+}
+
+func cpuTime(b *testing.B) time.Duration {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		b.Fatal(err)
+	}
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano())
+}
@@ -0,0 +1,143 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type deadlineTask struct{}
+
+var _ Task[string] = (*deadlineTask)(nil)
+
+func (t *deadlineTask) Execute(ctx context.Context) (string, error) {
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+type cancelableTask struct {
+	sleep  time.Duration
+	result string
+}
+
+var _ Task[string] = (*cancelableTask)(nil)
+
+func (t *cancelableTask) Execute(ctx context.Context) (string, error) {
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case <-time.After(t.sleep):
+		return t.result, nil
+	}
+}
+
+func TestExecuteAllCanceled(t *testing.T) {
+	p, err := NewThreadpool(1)
+	assert.Nil(t, err)
+	defer p.Shutdown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	results := ExecuteAll(ctx, NewSynchronousExecutor(p), []Task[string]{
+		&cancelableTask{sleep: 0, result: "done"},
+		&cancelableTask{sleep: 2 * time.Second, result: "slow"},
+		&cancelableTask{sleep: 2 * time.Second, result: "never started"},
+	})
+
+	assert.True(t, time.Since(start) < 1*time.Second, "the executor did not stop on cancellation")
+	assert.Len(t, results, 3)
+	assert.Equal(t, SynchronousResult[string]{Outcome: "done"}, results[0])
+	assert.Equal(t, SynchronousResult[string]{Error: context.Canceled}, results[1])
+	assert.Equal(t, SynchronousResult[string]{Error: context.Canceled}, results[2])
+}
+
+func TestExecuteAllAlreadyCanceled(t *testing.T) {
+	p, err := NewThreadpool(1)
+	assert.Nil(t, err)
+	defer p.Shutdown()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := ExecuteAll(ctx, NewSynchronousExecutor(p), []Task[string]{
+		&cancelableTask{result: "never started"},
+	})
+
+	assert.Equal(t, []SynchronousResult[string]{{Error: context.Canceled}}, results)
+}
+
+func TestTaskTimeout(t *testing.T) {
+	p, err := NewThreadpool(2)
+	assert.Nil(t, err)
+	defer p.Shutdown()
+
+	start := time.Now()
+	results := ExecuteAll(context.Background(),
+		NewSynchronousExecutor(p, WithTaskTimeout(50*time.Millisecond)),
+		[]Task[string]{
+			&deadlineTask{},
+			&deadlineTask{},
+		})
+
+	assert.True(t, time.Since(start) < 1*time.Second, "the task timeout was not applied")
+	for _, r := range results {
+		assert.Equal(t, context.DeadlineExceeded, r.Error)
+	}
+}
+
+func TestExecuteAllAsCompleted(t *testing.T) {
+	p, err := NewThreadpool(3)
+	assert.Nil(t, err)
+	defer p.Shutdown()
+
+	var indexes []int
+	var outcomes []string
+	for r := range ExecuteAllAsCompleted(context.Background(), NewSynchronousExecutor(p), []Task[string]{
+		&testTask{sleep: 200 * time.Millisecond, result: "slow"},
+		&testTask{sleep: 100 * time.Millisecond, result: "medium"},
+		&testTask{sleep: 0, result: "fast"},
+	}) {
+		assert.Nil(t, r.Error)
+		indexes = append(indexes, r.Index)
+		outcomes = append(outcomes, r.Outcome)
+	}
+
+	assert.Equal(t, []int{2, 1, 0}, indexes)
+	assert.Equal(t, []string{"fast", "medium", "slow"}, outcomes)
+}
+
+func TestExecuteAllKeepsTaskOrder(t *testing.T) {
+	p, err := NewThreadpool(3)
+	assert.Nil(t, err)
+	defer p.Shutdown()
+
+	e := NewSynchronousExecutor(p)
+	results := ExecuteAll(context.Background(), e, []Task[string]{
+		&testTask{sleep: 100 * time.Millisecond, result: "first"},
+		&testTask{sleep: 0, result: "second"},
+	})
+
+	assert.Equal(t, []SynchronousResult[string]{{Outcome: "first"}, {Outcome: "second"}}, results)
+	assert.Nil(t, ExecuteAll[string](context.Background(), e, nil))
+}
+
+func TestExecute(t *testing.T) {
+	p, err := NewThreadpool(1)
+	assert.Nil(t, err)
+	defer p.Shutdown()
+
+	e := NewSynchronousExecutor(p)
+	r, err := Execute[string](context.Background(), e, &testTask{result: "done"})
+	assert.Nil(t, err)
+	assert.Equal(t, "done", r)
+
+	_, err = Execute[string](context.Background(), e, &testTask{err: errors.New("access denied")})
+	assert.EqualError(t, err, "access denied")
+}
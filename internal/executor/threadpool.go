@@ -1,55 +1,135 @@
 package executor
 
 import (
+	"context"
+	"fmt"
 	"github.com/panjf2000/ants/v2"
-	"sync/atomic"
+	"runtime/debug"
 	"time"
 )
 
-type Task interface {
-	Execute() any
+// Task is a unit of work returning an outcome of type T or an error
+type Task[T any] interface {
+	Execute(ctx context.Context) (T, error)
 }
 
-type Future interface {
-	Get() any
-	GetWait() any
+// PanicError is the error of a task which panicked, the panic does not take down the worker
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("task panicked: %v\n%s", e.Value, e.Stack)
+}
+
+type Future[T any] interface {
+	// Get returns the outcome of the task, the zero value and no error while it is running
+	Get() (T, error)
+	GetWait() (T, error)
 	IsDone() bool
+	// Done is closed once the task has finished
+	Done() <-chan struct{}
+	// WaitContext waits for the task to finish, it gives up with the context error once the context is done
+	WaitContext(ctx context.Context) (T, error)
 }
 
-type taskFuture struct {
-	getResult func() any
-	isDone    func() bool
+type taskFuture[T any] struct {
+	done  chan struct{}
+	value T
+	err   error
 }
 
-var _ Future = (*taskFuture)(nil)
+var _ Future[any] = (*taskFuture[any])(nil)
+
+func newFuture[T any]() *taskFuture[T] {
+	return &taskFuture[T]{
+		done: make(chan struct{}),
+	}
+}
+
+// complete stores the outcome and releases the waiters, it must be called exactly once
+func (w *taskFuture[T]) complete(value T, err error) {
+	w.value = value
+	w.err = err
+	close(w.done)
+}
 
-func newFuture(getResult func() any, isDone func() bool) Future {
-	return &taskFuture{
-		getResult: getResult,
-		isDone:    isDone,
+func (w *taskFuture[T]) Get() (T, error) {
+	if !w.IsDone() {
+		var zero T
+		return zero, nil
 	}
+	return w.value, w.err
 }
 
-func (w *taskFuture) Get() any {
-	return w.getResult()
+func (w *taskFuture[T]) GetWait() (T, error) {
+	<-w.done
+	return w.value, w.err
 }
 
-func (w *taskFuture) GetWait() any {
-	for !w.isDone() {
-		time.Sleep(1 * time.Millisecond)
+func (w *taskFuture[T]) IsDone() bool {
+	select {
+	case <-w.done:
+		return true
+	default:
+		return false
 	}
-	return w.getResult()
 }
 
-func (w *taskFuture) IsDone() bool {
-	return w.isDone()
+func (w *taskFuture[T]) Done() <-chan struct{} {
+	return w.done
+}
+
+func (w *taskFuture[T]) WaitContext(ctx context.Context) (T, error) {
+	select {
+	case <-w.done:
+		return w.value, w.err
+	case <-ctx.Done():
+		// a task finishing at the same time as the cancellation still delivers its outcome
+		if w.IsDone() {
+			return w.value, w.err
+		}
+		var zero T
+		return zero, ctx.Err()
+	}
 }
 
 type Threadpool interface {
-	SubmitTask(t Task) (Future, error)
+	// Submit runs the function on a worker of the pool, it blocks while every worker is busy
+	Submit(fn func()) error
 	Shutdown()
 }
 
+// SubmitTask runs the task on the pool, the returned future completes with the outcome of the task
+func SubmitTask[T any](ctx context.Context, p Threadpool, t Task[T]) (Future[T], error) {
+	f := newFuture[T]()
+
+	err := p.Submit(func() {
+		f.complete(runTask(ctx, t))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// runTask executes the task turning a panic into a PanicError
+func runTask[T any](ctx context.Context, t Task[T]) (outcome T, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			var zero T
+			outcome, err = zero, &PanicError{
+				Value: r,
+				Stack: debug.Stack(),
+			}
+		}
+	}()
+
+	return t.Execute(ctx)
+}
+
 type antsThreadPool struct {
 	threadPool *ants.Pool
 }
@@ -67,33 +147,8 @@ func NewThreadpool(threads int) (Threadpool, error) {
 	}, nil
 }
 
-func (p *antsThreadPool) SubmitTask(t Task) (Future, error) {
-	value := atomic.Pointer[any]{}
-	done := atomic.Bool{}
-
-	exec := func() {
-		r := t.Execute()
-		value.Store(&r)
-		done.Store(true)
-	}
-
-	getResult := func() any {
-		if l := value.Load(); l != nil {
-			return *value.Load()
-		}
-		return nil
-	}
-
-	isDone := func() bool {
-		return done.Load()
-	}
-
-	err := p.threadPool.Submit(exec)
-	if err != nil {
-		return nil, err
-	}
-
-	return newFuture(getResult, isDone), nil
+func (p *antsThreadPool) Submit(fn func()) error {
+	return p.threadPool.Submit(fn)
 }
 
 func (p *antsThreadPool) Shutdown() {
@@ -1,6 +1,7 @@
 package executor
 
 import (
+	"context"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -10,13 +11,14 @@ import (
 type testTask struct {
 	sleep  time.Duration
 	result string
+	err    error
 }
 
-var _ Task = (*testTask)(nil)
+var _ Task[string] = (*testTask)(nil)
 
-func (t *testTask) Execute() any {
+func (t *testTask) Execute(_ context.Context) (string, error) {
 	time.Sleep(t.sleep)
-	return t.result
+	return t.result, t.err
 }
 
 func TestThreadPool(t *testing.T) {
@@ -26,12 +28,12 @@ func TestThreadPool(t *testing.T) {
 	)
 
 	start := time.Now()
-	var taskFutures []Future
+	var taskFutures []Future[string]
 
 	mgr, err := NewThreadpool(threads)
 	assert.Nil(t, err)
 	for i := 0; i < tasks; i++ {
-		tf, err := mgr.SubmitTask(&testTask{
+		tf, err := SubmitTask[string](context.Background(), mgr, &testTask{
 			sleep:  1 * time.Second,
 			result: fmt.Sprintf("result: %d", i),
 		})
@@ -41,13 +43,80 @@ func TestThreadPool(t *testing.T) {
 	}
 
 	for _, tf := range taskFutures {
-		resultRaw := tf.GetWait()
-		result, ok := resultRaw.(string)
-		assert.Truef(t, ok, "result is not a string")
-		assert.NotNilf(t, result, "result is nil")
+		result, err := tf.GetWait()
+		assert.Nil(t, err)
+		assert.NotEmptyf(t, result, "result is empty")
 	}
 
 	finished := time.Now()
 	deadline := start.Add(2100 * time.Millisecond)
 	assert.True(t, finished.Before(deadline), "threadpool was too slow")
 }
+
+func TestFutureWaitContext(t *testing.T) {
+	mgr, err := NewThreadpool(1)
+	assert.Nil(t, err)
+	defer mgr.Shutdown()
+
+	f, err := SubmitTask[string](context.Background(), mgr, &testTask{
+		sleep:  100 * time.Millisecond,
+		result: "finished",
+	})
+	assert.Nil(t, err)
+	assert.False(t, f.IsDone())
+	r, err := f.Get()
+	assert.Nil(t, err)
+	assert.Empty(t, r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	r, err = f.WaitContext(ctx)
+	assert.Empty(t, r)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	<-f.Done()
+	assert.True(t, f.IsDone())
+	r, err = f.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "finished", r)
+
+	r, err = f.WaitContext(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, "finished", r)
+}
+
+type panickingTask struct{}
+
+var _ Task[string] = (*panickingTask)(nil)
+
+func (t *panickingTask) Execute(_ context.Context) (string, error) {
+	var values map[string]string
+	values["key"] = "value"
+	return "unreachable", nil
+}
+
+func TestPanickingTask(t *testing.T) {
+	mgr, err := NewThreadpool(1)
+	assert.Nil(t, err)
+	defer mgr.Shutdown()
+
+	f, err := SubmitTask[string](context.Background(), mgr, &panickingTask{})
+	assert.Nil(t, err)
+
+	r, err := f.GetWait()
+	assert.Empty(t, r)
+
+	var panicErr *PanicError
+	assert.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "assignment to entry in nil map", fmt.Sprint(panicErr.Value))
+	assert.Contains(t, string(panicErr.Stack), "panickingTask")
+	assert.Contains(t, err.Error(), "task panicked: assignment to entry in nil map")
+
+	// the worker survived and the executor reports the panic as the task error
+	results := ExecuteAll(context.Background(), NewSynchronousExecutor(mgr), []Task[string]{
+		&panickingTask{},
+		&testTask{result: "still working"},
+	})
+	assert.ErrorAs(t, results[0].Error, &panicErr)
+	assert.Equal(t, SynchronousResult[string]{Outcome: "still working"}, results[1])
+}
@@ -0,0 +1,19 @@
+package args
+
+import "strings"
+
+const columnsSeparator = ","
+
+// ParseColumns returns the flattened property keys of the table outputs e.g.: "properties.engine", the keys
+// keep their case as the tag names are case-sensitive
+func ParseColumns(arg string) []string {
+	var columns []string
+	for _, c := range strings.Split(arg, columnsSeparator) {
+		c = strings.TrimSpace(c)
+		if c == "" {
+			continue
+		}
+		columns = append(columns, c)
+	}
+	return columns
+}
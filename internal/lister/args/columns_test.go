@@ -0,0 +1,14 @@
+package args
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseColumns(t *testing.T) {
+	assert.Nil(t, ParseColumns(""))
+	assert.Nil(t, ParseColumns(" , "))
+	assert.Equal(t,
+		[]string{"properties.engine", "properties.tags.Owner"},
+		ParseColumns("properties.engine, properties.tags.Owner,"))
+}
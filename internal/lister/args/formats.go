@@ -0,0 +1,25 @@
+package args
+
+import "slices"
+
+const (
+	FormatJSON   string = "json"
+	FormatNDJSON string = "ndjson"
+	FormatYAML   string = "yaml"
+
+	FormatDefault = FormatJSON
+)
+
+// ParseFormat returns the format of the file output, the default format is returned for an unknown one
+func ParseFormat(arg string) string {
+	f := sanitizeOutputArgs(arg)
+	if !slices.Contains(Formats(), f) {
+		return FormatDefault
+	}
+	return f
+}
+
+// Formats returns every supported format of the file output
+func Formats() []string {
+	return []string{FormatJSON, FormatNDJSON, FormatYAML}
+}
@@ -0,0 +1,14 @@
+package args
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	assert.Equal(t, "json", ParseFormat(""))
+	assert.Equal(t, "json", ParseFormat("json"))
+	assert.Equal(t, "ndjson", ParseFormat(" NDJSON "))
+	assert.Equal(t, "yaml", ParseFormat("yaml"))
+	assert.Equal(t, "json", ParseFormat("xml"))
+}
@@ -1,6 +1,7 @@
 package args
 
 import (
+	"fmt"
 	"slices"
 	"strings"
 )
@@ -8,8 +9,16 @@ import (
 const (
 	outputSeparator = ","
 
-	OutputStdout string = "stdout"
-	OutputFile   string = "file"
+	OutputStdout   string = "stdout"
+	OutputFile     string = "file"
+	OutputCSV      string = "csv"
+	OutputNDJSON   string = "ndjson"
+	OutputYAML     string = "yaml"
+	OutputSQLite   string = "sqlite"
+	OutputHTML     string = "html"
+	OutputTable    string = "table"
+	OutputMarkdown string = "markdown"
+	OutputTemplate string = "template"
 
 	OutputDefault = OutputStdout
 )
@@ -27,7 +36,7 @@ func ParseOutputs(arg string) []string {
 	}
 
 	var result []string
-	for _, output := range []string{OutputFile, OutputStdout} {
+	for _, output := range []string{OutputFile, OutputCSV, OutputStdout, OutputNDJSON, OutputYAML, OutputSQLite, OutputHTML, OutputTable, OutputMarkdown, OutputTemplate} {
 		if !slices.Contains(desiredOutputs, output) {
 			continue
 		}
@@ -36,6 +45,27 @@ func ParseOutputs(arg string) []string {
 	return result
 }
 
+// StdoutOutputs returns the outputs printing on the standard output, only one of them can be selected
+// as their content would interleave
+func StdoutOutputs() []string {
+	return []string{OutputStdout, OutputNDJSON, OutputYAML, OutputTable, OutputMarkdown, OutputTemplate}
+}
+
+// ValidateOutputs returns an error if the outputs select more than one of the StdoutOutputs
+func ValidateOutputs(outputs []string) error {
+	var printing []string
+	for _, o := range outputs {
+		if slices.Contains(StdoutOutputs(), o) {
+			printing = append(printing, o)
+		}
+	}
+	if len(printing) > 1 {
+		return fmt.Errorf("only one output can print on the standard output, selected: %s",
+			strings.Join(printing, outputSeparator))
+	}
+	return nil
+}
+
 func sanitizeOutputArgs(regions string) string {
 	r := regions
 	r = strings.ReplaceAll(r, " ", "")
@@ -32,4 +32,70 @@ func TestParseOutputArgs(t *testing.T) {
 		},
 		ParseOutputs("file,something"),
 	)
+
+	assert.Equal(t,
+		[]string{
+			"file",
+			"csv",
+			"stdout",
+		},
+		ParseOutputs("stdout,csv,file"),
+	)
+
+	assert.Equal(t,
+		[]string{
+			"file",
+			"ndjson",
+		},
+		ParseOutputs("ndjson,file"),
+	)
+
+	assert.Equal(t,
+		[]string{
+			"yaml",
+		},
+		ParseOutputs("yaml"),
+	)
+
+	assert.Equal(t,
+		[]string{
+			"csv",
+			"sqlite",
+		},
+		ParseOutputs("sqlite,csv"),
+	)
+
+	assert.Equal(t,
+		[]string{
+			"stdout",
+			"html",
+		},
+		ParseOutputs("html,stdout"),
+	)
+
+	assert.Equal(t,
+		[]string{
+			"table",
+			"markdown",
+		},
+		ParseOutputs("markdown,table"),
+	)
+
+	assert.Equal(t,
+		[]string{
+			"template",
+		},
+		ParseOutputs("template"),
+	)
+}
+
+func TestValidateOutputs(t *testing.T) {
+	assert.Nil(t, ValidateOutputs([]string{"file", "csv", "sqlite", "html", "stdout"}))
+	assert.Nil(t, ValidateOutputs([]string{"file", "table"}))
+	assert.Nil(t, ValidateOutputs(nil))
+
+	assert.EqualError(t,
+		ValidateOutputs(ParseOutputs("stdout,ndjson,file")),
+		"only one output can print on the standard output, selected: stdout,ndjson")
+	assert.NotNil(t, ValidateOutputs([]string{"yaml", "markdown"}))
 }
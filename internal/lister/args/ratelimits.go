@@ -0,0 +1,82 @@
+package args
+
+import (
+	"fmt"
+	conn "github.com/vcsomor/aws-resources/internal/aws_connector"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	rateLimitsSeparator = ","
+	rateLimitAssignment = "="
+	rateLimitRegion     = "/"
+)
+
+// ParseRateLimits parses the rate limits of the services e.g.: "s3=20/s,rds=5/s". A limit is for every region
+// of the service unless it names a region like "s3/eu-west-1=5/s", the rate can be given per second ("/s") or
+// per minute ("/m"), a bare number is per second
+func ParseRateLimits(arg string) ([]conn.RateLimit, error) {
+	var limits []conn.RateLimit
+	for _, spec := range strings.Split(strings.ToLower(arg), rateLimitsSeparator) {
+		spec = strings.TrimSpace(spec)
+		if spec == "" {
+			continue
+		}
+
+		l, err := parseRateLimit(spec)
+		if err != nil {
+			return nil, err
+		}
+		limits = append(limits, l)
+	}
+	return limits, nil
+}
+
+func parseRateLimit(spec string) (conn.RateLimit, error) {
+	target, rate, found := strings.Cut(spec, rateLimitAssignment)
+	if !found {
+		return conn.RateLimit{}, fmt.Errorf("invalid rate limit %q, expected e.g.: s3=20/s", spec)
+	}
+
+	service, region, _ := strings.Cut(strings.TrimSpace(target), rateLimitRegion)
+	if !slices.Contains(conn.RateLimitedServices(), service) {
+		return conn.RateLimit{}, fmt.Errorf("invalid rate limit %q, unknown service %q", spec, service)
+	}
+
+	perSecond, err := parseRate(strings.TrimSpace(rate))
+	if err != nil {
+		return conn.RateLimit{}, fmt.Errorf("invalid rate limit %q: %w", spec, err)
+	}
+
+	return conn.RateLimit{
+		Service:   service,
+		Region:    region,
+		PerSecond: perSecond,
+	}, nil
+}
+
+func parseRate(rate string) (float64, error) {
+	per := 1.0
+	switch {
+	case strings.HasSuffix(rate, "/s"):
+		rate = strings.TrimSuffix(rate, "/s")
+	case strings.HasSuffix(rate, "/m"):
+		rate, per = strings.TrimSuffix(rate, "/m"), 60
+	}
+
+	n, err := strconv.ParseFloat(rate, 64)
+	if err != nil {
+		return 0, err
+	}
+	// ParseFloat accepts "nan" and "inf" too, a bucket with such a rate would never wait
+	if math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, fmt.Errorf("the rate has to be a finite number")
+	}
+	if n <= 0 {
+		return 0, fmt.Errorf("the rate has to be positive")
+	}
+	return n / per, nil
+}
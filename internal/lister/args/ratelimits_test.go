@@ -0,0 +1,29 @@
+package args
+
+import (
+	"github.com/stretchr/testify/assert"
+	conn "github.com/vcsomor/aws-resources/internal/aws_connector"
+	"testing"
+)
+
+func TestParseRateLimits(t *testing.T) {
+	limits, err := ParseRateLimits("")
+	assert.Nil(t, err)
+	assert.Nil(t, limits)
+
+	limits, err = ParseRateLimits("S3=20/s, rds=300/m,ec2=2.5,s3/eu-west-1=5/s")
+	assert.Nil(t, err)
+	assert.Equal(t,
+		[]conn.RateLimit{
+			{Service: "s3", PerSecond: 20},
+			{Service: "rds", PerSecond: 5},
+			{Service: "ec2", PerSecond: 2.5},
+			{Service: "s3", Region: "eu-west-1", PerSecond: 5},
+		},
+		limits)
+
+	for _, invalid := range []string{"s3", "s3=fast", "s3=0/s", "dynamodb=5/s", "s3=-1", "s3=nan", "s3=NaN/s", "s3=inf", "s3=+Inf/m", "rds=-inf", "s3=1e400"} {
+		_, err = ParseRateLimits(invalid)
+		assert.NotNil(t, err, invalid)
+	}
+}
@@ -1,6 +1,7 @@
 package args
 
 import (
+	"github.com/vcsomor/aws-resources/internal/lister"
 	"slices"
 	"strings"
 )
@@ -8,14 +9,9 @@ import (
 const resourcesSeparator = ","
 
 const resourcesAll string = "all"
-const resourcesS3 string = "s3"
-const resourcesRDS string = "rds"
 
 func allResources() []string {
-	return []string{
-		resourcesS3,
-		resourcesRDS,
-	}
+	return lister.ResourceTypes()
 }
 
 func ParseResources(res string) []string {
@@ -10,6 +10,7 @@ func TestResourcesAll(t *testing.T) {
 		[]string{
 			"s3",
 			"rds",
+			"ec2",
 		},
 		allResources())
 }
@@ -24,6 +25,7 @@ func TestParseResourceArgs(t *testing.T) {
 		[]string{
 			"s3",
 			"rds",
+			"ec2",
 		},
 		ParseResources(""),
 	)
@@ -32,6 +34,7 @@ func TestParseResourceArgs(t *testing.T) {
 		[]string{
 			"s3",
 			"rds",
+			"ec2",
 		},
 		ParseResources("all"),
 	)
@@ -44,6 +47,14 @@ func TestParseResourceArgs(t *testing.T) {
 		ParseResources("rds,s3"),
 	)
 
+	assert.Equal(t,
+		[]string{
+			"rds",
+			"ec2",
+		},
+		ParseResources("ec2,rds"),
+	)
+
 	assert.Equal(t,
 		[]string{
 			"s3",
@@ -0,0 +1,32 @@
+package args
+
+import (
+	"github.com/vcsomor/aws-resources/internal/lister"
+	"strings"
+)
+
+const (
+	tagsSeparator  = ","
+	tagsAssignment = "="
+)
+
+// ParseTagFilter parses the tags the resources have to have e.g.: "Environment=prod,Owner", a tag without
+// a value matches any value. The tags keep their case.
+func ParseTagFilter(arg string) lister.TagFilter {
+	f := lister.TagFilter{}
+	for _, tag := range strings.Split(arg, tagsSeparator) {
+		key, value, hasValue := strings.Cut(tag, tagsAssignment)
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+
+		if !hasValue {
+			f[key] = nil
+			continue
+		}
+		v := strings.TrimSpace(value)
+		f[key] = &v
+	}
+	return f
+}
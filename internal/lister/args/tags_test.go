@@ -0,0 +1,19 @@
+package args
+
+import (
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/vcsomor/aws-resources/internal/lister"
+	"testing"
+)
+
+func TestParseTagFilter(t *testing.T) {
+	assert.Equal(t, lister.TagFilter{}, ParseTagFilter(""))
+	assert.Equal(t,
+		lister.TagFilter{
+			"Environment": ptr.String("prod"),
+			"Owner":       nil,
+			"CostCenter":  ptr.String(""),
+		},
+		ParseTagFilter("Environment=prod, Owner,CostCenter=,"))
+}
@@ -3,55 +3,95 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/vcsomor/aws-resources/config"
 	conn "github.com/vcsomor/aws-resources/internal/aws_connector"
 	"github.com/vcsomor/aws-resources/internal/executor"
 	"github.com/vcsomor/aws-resources/internal/lister"
 	"github.com/vcsomor/aws-resources/internal/lister/args"
-	"github.com/vcsomor/aws-resources/internal/lister/writer/jsonfile"
-	"github.com/vcsomor/aws-resources/internal/lister/writer/stdout"
 	"github.com/vcsomor/aws-resources/log"
-	"slices"
 	"strconv"
-	"strings"
+	"time"
 )
 
 // ListResources is the command entry point
 func ListResources(command *cobra.Command, _ []string) {
 	logger := log.NewLogger(config.Config())
 
-	th := command.Flag("threads").
-		Value.
-		String()
+	profile, err := scanProfile(command)
+	if err != nil {
+		logger.WithError(err).
+			Error("invalid scan profile")
+		return
+	}
+	defaults := profileFlags(profile)
 
-	threadCount, err := strconv.Atoi(th)
+	threadCount, err := strconv.Atoi(flagValue(command, "threads", defaults))
 	if err != nil {
 		logger.WithError(err).
 			Error("invalid thread count")
 		return
 	}
 
-	argRegions := args.ParseRegions(command.Flag("regions").
-		Value.
-		String())
+	pageSize, err := strconv.ParseInt(flagValue(command, "page-size", defaults), 10, 32)
+	if err != nil || pageSize < 0 {
+		logger.WithError(err).
+			Error("invalid page size")
+		return
+	}
+	logger.Debugf("page size: %v", pageSize)
+
+	argRegions := args.ParseRegions(flagValue(command, "regions", defaults))
 	logger.Debugf("regions: %v", argRegions)
 
-	argResources := args.ParseResources(command.Flag("resources").
-		Value.
-		String())
+	argResources := args.ParseResources(flagValue(command, "resources", defaults))
 	logger.Debugf("resources: %v", argResources)
 
-	argOutputs := args.ParseOutputs(command.Flag("output").
-		Value.
-		String())
+	argOutputs := args.ParseOutputs(flagValue(command, "output", defaults))
 	logger.Debugf("output: %v", argOutputs)
 
-	argTarget := command.Flag("target").
-		Value.
-		String()
+	argTarget := flagValue(command, "target", defaults)
 	logger.Debugf("target: %v", argTarget)
 
+	argFormat := args.ParseFormat(flagValue(command, "format", defaults))
+	logger.Debugf("format: %v", argFormat)
+
+	argColumns := args.ParseColumns(flagValue(command, "columns", defaults))
+	logger.Debugf("columns: %v", argColumns)
+
+	argTemplate := flagValue(command, "template", defaults)
+	logger.Debugf("template: %v", argTemplate)
+
+	argTags := tagFilter(command, profile)
+	logger.Debugf("tags: %v", argTags)
+
+	taskTimeout, err := time.ParseDuration(flagValue(command, "task-timeout", defaults))
+	if err != nil {
+		logger.WithError(err).
+			Error("invalid task timeout")
+		return
+	}
+	logger.Debugf("task timeout: %v", taskTimeout)
+
+	rateLimits, err := args.ParseRateLimits(flagValue(command, "rate-limit", defaults))
+	if err != nil {
+		logger.WithError(err).
+			Error("invalid rate limit")
+		return
+	}
+	logger.Debugf("rate limits: %v", rateLimits)
+
+	awsProfile := flagValue(command, "aws-profile", defaults)
+	logger.Debugf("aws profile: %v", awsProfile)
+
+	assumeRole, err := assumeRoleArgs(command, defaults)
+	if err != nil {
+		logger.WithError(err).
+			Error("invalid role assumption")
+		return
+	}
+
 	threadpool, err := executor.NewThreadpool(threadCount)
 	if err != nil {
 		logger.WithError(err).
@@ -65,51 +105,136 @@ func ListResources(command *cobra.Command, _ []string) {
 		}
 	}()
 
+	ctx := command.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var factoryOpts []conn.ClientFactoryOptionFnc
+	var rateLimiter *conn.RateLimiter
+	if len(rateLimits) > 0 {
+		rateLimiter = conn.NewRateLimiter(rateLimits)
+		factoryOpts = append(factoryOpts, conn.WithRateLimiter(rateLimiter))
+	}
+	if awsProfile != "" {
+		factoryOpts = append(factoryOpts, conn.WithAWSProfile(awsProfile))
+	}
+	if assumeRole != nil {
+		factoryOpts = append(factoryOpts, conn.WithAssumeRole(*assumeRole))
+	}
+
+	clientFactory := conn.NewClientFactory(logger, factoryOpts...)
+	startedAt := time.Now()
+	identity := callerIdentity(ctx, clientFactory, logger)
+
 	deps := []lister.DependencyFn{
-		lister.WithClientFactory(conn.NewClientFactory(logger)),
+		lister.WithClientFactory(clientFactory),
 		lister.WithLogger(logger),
-		lister.WithExecutor(executor.NewSynchronousExecutor(threadpool)),
+		lister.WithExecutor(executor.NewSynchronousExecutor(threadpool, executor.WithTaskTimeout(taskTimeout))),
+	}
+
+	outputs, err := newOutputs(argOutputs, argTarget, argFormat, argColumns, argTemplate, logger)
+	if err != nil {
+		logger.WithError(err).
+			Error("unable to create the outputs")
+		return
 	}
 
-	res := lister.NewLister().
+	counter := lister.NewCounter()
+	report := lister.NewLister().
 		Dependencies(deps...).
 		Parameters(
 			lister.WithRegions(argRegions),
 			lister.WithResources(argResources),
+			lister.WithPageSize(int32(pageSize)),
 		).
 		Build().
-		List(context.TODO())
+		Stream(ctx, lister.Filter(argTags, lister.Tee(counter.Put, outputs.put)))
 
-	if slices.Contains(argOutputs, args.OutputFile) {
-		writeOutputFiles(argTarget, res)
+	interrupted := ctx.Err() != nil
+	if interrupted {
+		logger.Warn("listing interrupted, writing partial results")
 	}
 
-	if slices.Contains(argOutputs, args.OutputStdout) {
-		writeStandardOut(res)
-	}
+	manifest := lister.NewManifest(lister.ManifestParams{
+		Identity:   identity,
+		AWSProfile: awsProfile,
+		AssumeRole: assumeRole,
+		Regions:    argRegions,
+		Resources:  argResources,
+		StartedAt:  startedAt,
+		FinishedAt: time.Now(),
+
+		Interrupted:    interrupted,
+		RateLimitWaits: rateLimitWaits(rateLimiter, logger),
+	}, counter.Counts())
+
+	outputs.finish(lister.NewEnvelope(manifest, report))
 }
 
-func writeOutputFiles(toFolder string, res []lister.Result) {
-	for _, result := range res {
-		w, err := jsonfile.NewWriter(
-			toFolder,
-			jsonfile.WithOutputFile(fmt.Sprintf("%s.json", stripArn(result.Arn))),
-			jsonfile.WithIndentation("\t"))
-		if err != nil {
-			continue
-		}
-		_ = w.Write(result)
+func callerIdentity(ctx context.Context, factory conn.ClientFactory, logger *logrus.Logger) conn.CallerIdentityResult {
+	client, err := factory.STSClient(ctx, nil)
+	if err != nil {
+		return conn.CallerIdentityResult{}
 	}
-}
 
-func writeStandardOut(res []lister.Result) {
-	w, err := stdout.NewWriter(stdout.WithIndentation("\t"))
+	identity, err := client.CallerIdentity(ctx)
 	if err != nil {
-		return
+		logger.WithError(err).
+			Warn("unable to get the caller identity")
+		return conn.CallerIdentityResult{}
+	}
+	return identity
+}
+
+// rateLimitWaits logs how long the requests waited for the rate limits and returns the waits for the manifest
+func rateLimitWaits(l *conn.RateLimiter, logger *logrus.Logger) []conn.RateLimitWait {
+	waits := l.Waits()
+	for _, w := range waits {
+		logger.WithField("service", w.Service).
+			WithField("region", w.Region).
+			WithField("requests", w.Requests).
+			WithField("delayed", w.Delayed).
+			WithField("total-wait", w.Total).
+			WithField("max-wait", w.Max).
+			Info("rate limit waits")
 	}
-	_ = w.Write(res)
+	return waits
 }
 
-func stripArn(arn string) any {
-	return strings.ReplaceAll(arn, ":", "_")
+// assumeRoleArgs returns the role to assume, nil if no role is given
+func assumeRoleArgs(command *cobra.Command, defaults map[string]string) (*conn.AssumeRole, error) {
+	r := conn.AssumeRole{
+		RoleArn:     flagValue(command, "assume-role-arn", defaults),
+		ExternalID:  flagValue(command, "external-id", defaults),
+		SessionName: flagValue(command, "role-session-name", defaults),
+		MFASerial:   flagValue(command, "mfa-serial", defaults),
+		MFAToken:    flagValue(command, "mfa-token", defaults),
+	}
+
+	duration := flagValue(command, "role-duration", defaults)
+	if duration != "" {
+		d, err := time.ParseDuration(duration)
+		if err != nil {
+			return nil, fmt.Errorf("invalid role session duration: %w", err)
+		}
+		if d < 15*time.Minute {
+			return nil, fmt.Errorf("the role session has to last at least 15 minutes: %v", d)
+		}
+		r.Duration = d
+	}
+
+	if r.RoleArn == "" {
+		if r.ExternalID != "" || r.SessionName != "" || r.Duration > 0 || r.MFASerial != "" || r.MFAToken != "" {
+			return nil, fmt.Errorf("the external ID, the session options and the MFA options need a role to assume")
+		}
+		return nil, nil
+	}
+	if r.MFAToken != "" && r.MFASerial == "" {
+		return nil, fmt.Errorf("the MFA token needs the serial of the MFA device")
+	}
+	if r.SessionName == "" {
+		r.SessionName = conn.DefaultRoleSessionName(time.Now())
+	}
+	return &r, nil
 }
@@ -0,0 +1,406 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"github.com/vcsomor/aws-resources/internal/lister"
+	"github.com/vcsomor/aws-resources/internal/lister/args"
+	"github.com/vcsomor/aws-resources/internal/lister/writer"
+	"github.com/vcsomor/aws-resources/internal/lister/writer/csv"
+	"github.com/vcsomor/aws-resources/internal/lister/writer/htmlreport"
+	"github.com/vcsomor/aws-resources/internal/lister/writer/jsonfile"
+	"github.com/vcsomor/aws-resources/internal/lister/writer/ndjson"
+	"github.com/vcsomor/aws-resources/internal/lister/writer/sqlite"
+	"github.com/vcsomor/aws-resources/internal/lister/writer/stdout"
+	"github.com/vcsomor/aws-resources/internal/lister/writer/table"
+	"github.com/vcsomor/aws-resources/internal/lister/writer/template"
+	"github.com/vcsomor/aws-resources/internal/lister/writer/yaml"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	errorsFile    = "errors.json"
+	manifestFile  = "manifest.json"
+	resourcesFile = "resources.ndjson"
+)
+
+// output receives every resource while the listing runs and gets completed once it has finished
+type output interface {
+	put(r lister.Result) error
+	finish(envelope lister.Envelope) error
+}
+
+// outputs hands the resources over to every output, one resource at a time
+type outputs struct {
+	mu      sync.Mutex
+	outputs []output
+	logger  *logrus.Logger
+}
+
+func newOutputs(
+	selected []string,
+	target string,
+	format string,
+	columns []string,
+	templateFile string,
+	logger *logrus.Logger,
+) (*outputs, error) {
+	if err := args.ValidateOutputs(selected); err != nil {
+		return nil, err
+	}
+
+	o := &outputs{
+		logger: logger,
+	}
+
+	if slices.Contains(selected, args.OutputFile) {
+		out, err := newFileOutput(target, format)
+		if err != nil {
+			return nil, err
+		}
+		o.outputs = append(o.outputs, out)
+	}
+
+	if slices.Contains(selected, args.OutputCSV) {
+		o.outputs = append(o.outputs, &csvOutput{target: target})
+	}
+
+	if slices.Contains(selected, args.OutputStdout) {
+		w, err := stdout.NewStreamWriter(lister.EnvelopeResourcesKey, stdout.WithIndentation("\t"))
+		if err != nil {
+			return nil, err
+		}
+		o.outputs = append(o.outputs, &stdoutOutput{w: w})
+	}
+
+	if slices.Contains(selected, args.OutputNDJSON) {
+		w, err := ndjson.NewWriter()
+		if err != nil {
+			return nil, err
+		}
+		o.outputs = append(o.outputs, &ndjsonOutput{w: w})
+	}
+
+	if slices.Contains(selected, args.OutputYAML) {
+		w, err := yaml.NewStreamWriter(lister.EnvelopeResourcesKey)
+		if err != nil {
+			return nil, err
+		}
+		o.outputs = append(o.outputs, &yamlOutput{w: w})
+	}
+
+	if slices.Contains(selected, args.OutputSQLite) {
+		w, err := sqlite.NewWriter(target)
+		if err != nil {
+			return nil, err
+		}
+		o.outputs = append(o.outputs, &sqliteOutput{w: w})
+	}
+
+	if slices.Contains(selected, args.OutputHTML) {
+		o.outputs = append(o.outputs, &htmlOutput{target: target})
+	}
+
+	if slices.Contains(selected, args.OutputTable) {
+		w, err := table.NewWriter(table.WithColumns(columns...))
+		if err != nil {
+			return nil, err
+		}
+		o.outputs = append(o.outputs, &tableOutput{w: w})
+	}
+
+	if slices.Contains(selected, args.OutputMarkdown) {
+		w, err := table.NewWriter(table.WithColumns(columns...), table.WithMarkdown())
+		if err != nil {
+			return nil, err
+		}
+		o.outputs = append(o.outputs, &tableOutput{w: w})
+	}
+
+	if slices.Contains(selected, args.OutputTemplate) {
+		if templateFile == "" {
+			return nil, fmt.Errorf("the template output needs a template file")
+		}
+		w, err := template.NewWriter(templateFile)
+		if err != nil {
+			return nil, err
+		}
+		o.outputs = append(o.outputs, &templateOutput{w: w})
+	}
+
+	return o, nil
+}
+
+func (o *outputs) put(r lister.Result) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, out := range o.outputs {
+		if err := out.put(r); err != nil {
+			o.logger.WithError(err).
+				WithField("arn", r.Arn).
+				Error("unable to write the resource")
+		}
+	}
+}
+
+func (o *outputs) finish(envelope lister.Envelope) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, out := range o.outputs {
+		if err := out.finish(envelope); err != nil {
+			o.logger.WithError(err).
+				Error("unable to complete the output")
+		}
+	}
+}
+
+// fileOutput writes the resources into the target directory as soon as they are listed: a JSON or
+// YAML file per resource or a line per resource into a single NDJSON file
+type fileOutput struct {
+	target string
+	format string
+
+	file  *os.File
+	lines *ndjsonOutput
+}
+
+func newFileOutput(target string, format string) (*fileOutput, error) {
+	o := &fileOutput{
+		target: target,
+		format: format,
+	}
+	if format != args.FormatNDJSON {
+		return o, nil
+	}
+
+	if err := os.MkdirAll(target, os.ModePerm); err != nil {
+		return nil, err
+	}
+	f, err := os.Create(filepath.Join(target, resourcesFile))
+	if err != nil {
+		return nil, err
+	}
+	w, err := ndjson.NewWriter(ndjson.WithOutput(f))
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	o.file = f
+	o.lines = &ndjsonOutput{w: w}
+	return o, nil
+}
+
+func (o *fileOutput) put(r lister.Result) error {
+	if o.lines != nil {
+		return o.lines.put(r)
+	}
+	if o.format == args.FormatYAML {
+		return writeYAMLFile(o.target, fmt.Sprintf("%s.yaml", stripArn(r.Arn)), r)
+	}
+	return writeJSONFile(o.target, fmt.Sprintf("%s.json", stripArn(r.Arn)), r)
+}
+
+func (o *fileOutput) finish(envelope lister.Envelope) error {
+	var errs []error
+	if o.file != nil {
+		errs = append(errs, o.file.Close())
+	}
+	return errors.Join(append(errs,
+		writeJSONFile(o.target, errorsFile, lister.Report{Errors: envelope.Errors}),
+		writeJSONFile(o.target, manifestFile, envelope.Manifest),
+	)...)
+}
+
+// csvOutput keeps the resources until the end as the columns of a CSV file depend on every row
+type csvOutput struct {
+	target string
+
+	resourceTypes []string
+	byType        map[string][]lister.Result
+}
+
+func (o *csvOutput) put(r lister.Result) error {
+	if o.byType == nil {
+		o.byType = map[string][]lister.Result{}
+	}
+
+	t := r.ResourceType()
+	if _, exist := o.byType[t]; !exist {
+		o.resourceTypes = append(o.resourceTypes, t)
+	}
+	o.byType[t] = append(o.byType[t], r)
+	return nil
+}
+
+func (o *csvOutput) finish(_ lister.Envelope) error {
+	var errs []error
+	for _, t := range o.resourceTypes {
+		w, err := csv.NewWriter(
+			o.target,
+			csv.WithOutputFile(fmt.Sprintf("%s.csv", t)))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		errs = append(errs, w.Write(o.byType[t]))
+	}
+	return errors.Join(errs...)
+}
+
+// stdoutOutput prints the resources as they are listed, the errors and the manifest follow them
+type stdoutOutput struct {
+	w *stdout.StreamWriter
+}
+
+func (o *stdoutOutput) put(r lister.Result) error {
+	return o.w.Write(r)
+}
+
+func (o *stdoutOutput) finish(envelope lister.Envelope) error {
+	return o.w.Close(envelope)
+}
+
+// ndjsonOutput writes a compact JSON line per resource which carries its resource type
+type ndjsonOutput struct {
+	w writer.Writer
+}
+
+func (o *ndjsonOutput) put(r lister.Result) error {
+	return o.w.Write(r.Typed())
+}
+
+func (o *ndjsonOutput) finish(_ lister.Envelope) error {
+	return nil
+}
+
+// yamlOutput prints the resources as they are listed, the errors and the manifest follow them
+type yamlOutput struct {
+	w *yaml.StreamWriter
+}
+
+func (o *yamlOutput) put(r lister.Result) error {
+	return o.w.Write(r)
+}
+
+func (o *yamlOutput) finish(envelope lister.Envelope) error {
+	return o.w.Close(envelope)
+}
+
+// sqliteOutput adds the scan to the inventory database of the target directory, the rows are
+// committed once the listing has finished
+type sqliteOutput struct {
+	w *sqlite.Writer
+}
+
+func (o *sqliteOutput) put(r lister.Result) error {
+	return o.w.Write(sqlite.Resource{
+		Arn:          r.Arn,
+		Type:         r.ResourceType(),
+		Region:       r.Region(),
+		CreationTime: r.CreationTime,
+		Properties:   r.Properties,
+		Tags:         r.Tags(),
+	})
+}
+
+func (o *sqliteOutput) finish(envelope lister.Envelope) error {
+	return o.w.Close(sqlite.Scan{
+		Timestamp: envelope.Manifest.StartedAt,
+		Account:   envelope.Manifest.Account,
+		Version:   envelope.Manifest.Build.Version,
+	})
+}
+
+// htmlOutput keeps the resources until the end as the report summarizes every resource
+type htmlOutput struct {
+	target    string
+	resources []lister.Result
+}
+
+func (o *htmlOutput) put(r lister.Result) error {
+	o.resources = append(o.resources, r)
+	return nil
+}
+
+func (o *htmlOutput) finish(envelope lister.Envelope) error {
+	w, err := htmlreport.NewWriter(o.target)
+	if err != nil {
+		return err
+	}
+	return w.Write(htmlreport.Report{
+		Manifest:  envelope.Manifest,
+		Resources: o.resources,
+		Errors:    envelope.Errors,
+	})
+}
+
+// tableOutput keeps the resources until the end as the columns are aligned to the widest value
+type tableOutput struct {
+	w         writer.Writer
+	resources []lister.Result
+}
+
+func (o *tableOutput) put(r lister.Result) error {
+	o.resources = append(o.resources, r)
+	return nil
+}
+
+func (o *tableOutput) finish(_ lister.Envelope) error {
+	return o.w.Write(o.resources)
+}
+
+// templateOutput keeps the resources until the end as the template renders the whole result set
+type templateOutput struct {
+	w         writer.Writer
+	resources []lister.Result
+}
+
+func (o *templateOutput) put(r lister.Result) error {
+	o.resources = append(o.resources, r)
+	return nil
+}
+
+func (o *templateOutput) finish(envelope lister.Envelope) error {
+	return o.w.Write(template.Data{
+		Resources: o.resources,
+		Errors:    envelope.Errors,
+		Manifest:  envelope.Manifest,
+	})
+}
+
+func writeYAMLFile(toFolder string, file string, obj any) error {
+	w, err := yaml.NewWriter(
+		toFolder,
+		yaml.WithOutputFile(file))
+	if err != nil {
+		return err
+	}
+	return w.Write(obj)
+}
+
+func writeJSONFile(toFolder string, file string, obj any) error {
+	w, err := jsonfile.NewWriter(
+		toFolder,
+		jsonfile.WithOutputFile(file),
+		jsonfile.WithIndentation("\t"))
+	if err != nil {
+		return err
+	}
+	return w.Write(obj)
+}
+
+// arnFileName replaces the separators of an ARN, the resource part of many ARNs has a "/" e.g.:
+// "instance/i-0123456789abcdef0" which would be a sub-directory of the target
+var arnFileName = strings.NewReplacer(":", "_", "/", "_", "\\", "_")
+
+func stripArn(arn string) string {
+	return arnFileName.Replace(arn)
+}
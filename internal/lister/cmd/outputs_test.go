@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/vcsomor/aws-resources/internal/lister"
+	"github.com/vcsomor/aws-resources/internal/lister/args"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testEC2Arn = "arn:aws:ec2:eu-west-1:123456789012:instance/i-0123456789abcdef0"
+
+func TestStripArn(t *testing.T) {
+	assert.Equal(t, "arn_aws_s3___my-bucket", stripArn("arn:aws:s3:::my-bucket"))
+	assert.Equal(t,
+		"arn_aws_ec2_eu-west-1_123456789012_instance_i-0123456789abcdef0",
+		stripArn(testEC2Arn))
+	assert.Equal(t, "a_b_c", stripArn(`a/b\c`))
+}
+
+func TestOutputsPrintingOnStdout(t *testing.T) {
+	_, err := newOutputs([]string{args.OutputStdout, args.OutputYAML}, t.TempDir(), args.FormatJSON, nil, "", logrus.New())
+	assert.ErrorContains(t, err, "only one output can print on the standard output")
+}
+
+func TestFileOutputWithEC2Arn(t *testing.T) {
+	for _, tc := range []struct {
+		format string
+		file   string
+	}{
+		{format: args.FormatJSON, file: "arn_aws_ec2_eu-west-1_123456789012_instance_i-0123456789abcdef0.json"},
+		{format: args.FormatYAML, file: "arn_aws_ec2_eu-west-1_123456789012_instance_i-0123456789abcdef0.yaml"},
+	} {
+		t.Run(tc.format, func(t *testing.T) {
+			target := t.TempDir()
+			out, err := newFileOutput(target, tc.format)
+			assert.Nil(t, err)
+
+			assert.Nil(t, out.put(lister.Result{
+				Arn:        testEC2Arn,
+				ID:         "i-0123456789abcdef0",
+				Properties: lister.EC2Data{InstanceType: "t3.micro", State: "running"},
+			}))
+			assert.Nil(t, out.finish(lister.Envelope{}))
+
+			entries, err := os.ReadDir(target)
+			assert.Nil(t, err)
+			for _, e := range entries {
+				assert.False(t, e.IsDir(), e.Name())
+			}
+
+			content, err := os.ReadFile(filepath.Join(target, tc.file))
+			assert.Nil(t, err)
+			assert.Contains(t, string(content), "i-0123456789abcdef0")
+		})
+	}
+}
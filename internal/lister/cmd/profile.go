@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/vcsomor/aws-resources/config"
+	"github.com/vcsomor/aws-resources/internal/lister"
+	"github.com/vcsomor/aws-resources/internal/lister/args"
+	"strconv"
+	"strings"
+)
+
+const defaultScanProfile = "default"
+
+// scanProfile returns the scan profile selected with --scan-profile from the --config file. Without
+// --scan-profile the "default" profile is used if the config file has one.
+func scanProfile(command *cobra.Command) (config.ScanProfile, error) {
+	configFlag := command.Flag("config")
+	path := configFlag.Value.String()
+	if path == "" {
+		path = config.DefaultScanConfigPath()
+	}
+
+	c, err := config.LoadScanConfig(path, configFlag.Changed)
+	if err != nil {
+		return config.ScanProfile{}, err
+	}
+
+	profileFlag := command.Flag("scan-profile")
+	name := profileFlag.Value.String()
+	if !profileFlag.Changed {
+		if _, exist := c.Profiles[defaultScanProfile]; !exist {
+			return config.ScanProfile{}, nil
+		}
+		name = defaultScanProfile
+	}
+	return c.Profile(name)
+}
+
+// profileFlags returns the values of the scan profile in the format of the flags they stand for
+func profileFlags(p config.ScanProfile) map[string]string {
+	flags := map[string]string{}
+	set := func(name string, value string) {
+		if value != "" {
+			flags[name] = value
+		}
+	}
+
+	set("regions", strings.Join(p.Regions, ","))
+	set("resources", strings.Join(p.Resources, ","))
+	set("output", strings.Join(p.Outputs, ","))
+	set("target", p.Target)
+	set("format", p.Format)
+	set("columns", strings.Join(p.Columns, ","))
+	set("template", p.Template)
+	if p.Threads > 0 {
+		set("threads", strconv.Itoa(p.Threads))
+	}
+	if p.PageSize > 0 {
+		set("page-size", strconv.Itoa(p.PageSize))
+	}
+	set("task-timeout", p.TaskTimeout)
+	set("rate-limit", strings.Join(p.RateLimits, ","))
+	set("aws-profile", p.AWSProfile)
+	set("assume-role-arn", p.AssumeRoleArn)
+	set("external-id", p.ExternalID)
+	set("role-session-name", p.RoleSessionName)
+	set("role-duration", p.RoleDuration)
+	set("mfa-serial", p.MFASerial)
+	return flags
+}
+
+// tagFilter returns the tags given with --tags, otherwise the tags of the scan profile. The tags of the
+// profile are taken as they are, so their keys and values can have a "," or a "=" in them.
+func tagFilter(command *cobra.Command, p config.ScanProfile) lister.TagFilter {
+	f := command.Flag("tags")
+	if f.Changed || len(p.Filters.Tags) == 0 {
+		return args.ParseTagFilter(f.Value.String())
+	}
+
+	tags := lister.TagFilter{}
+	for k, v := range p.Filters.Tags {
+		tags[k] = v
+	}
+	return tags
+}
+
+// flagValue returns the value of the flag if it was given, otherwise the value of the scan profile
+// or the default of the flag
+func flagValue(command *cobra.Command, name string, profile map[string]string) string {
+	f := command.Flag(name)
+	if v, exist := profile[name]; exist && !f.Changed {
+		return v
+	}
+	return f.Value.String()
+}
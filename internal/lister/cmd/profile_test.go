@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"github.com/aws/smithy-go/ptr"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/vcsomor/aws-resources/config"
+	"github.com/vcsomor/aws-resources/internal/lister"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testConfig = `
+profiles:
+  default:
+    outputs: [table]
+  nightly:
+    regions: [eu-west-1, us-east-1]
+    outputs: [file, sqlite]
+    threads: 16
+    filters:
+      tags:
+        Owner:
+        Environment: prod
+`
+
+func testCommand(t *testing.T, args ...string) *cobra.Command {
+	command := &cobra.Command{}
+	for name, value := range map[string]string{
+		"config":       "",
+		"scan-profile": "",
+		"regions":      "all",
+		"output":       "stdout",
+		"threads":      "2",
+		"tags":         "",
+	} {
+		command.Flags().String(name, value, "")
+	}
+	assert.Nil(t, command.ParseFlags(args))
+	return command
+}
+
+func writeTestConfig(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), config.DefaultScanConfigFile)
+	assert.Nil(t, os.WriteFile(path, []byte(testConfig), 0o600))
+	return path
+}
+
+func TestScanProfileOverriddenByFlags(t *testing.T) {
+	command := testCommand(t,
+		"--config", writeTestConfig(t),
+		"--scan-profile", "nightly",
+		"--threads", "4")
+
+	p, err := scanProfile(command)
+	assert.Nil(t, err)
+	defaults := profileFlags(p)
+
+	assert.Equal(t, "eu-west-1,us-east-1", flagValue(command, "regions", defaults))
+	assert.Equal(t, "file,sqlite", flagValue(command, "output", defaults))
+	assert.Equal(t, "4", flagValue(command, "threads", defaults))
+	assert.Equal(t, lister.TagFilter{"Environment": ptr.String("prod"), "Owner": nil}, tagFilter(command, p))
+}
+
+func TestProfileTagsAreNotReparsed(t *testing.T) {
+	p := config.ScanProfile{
+		Filters: config.ScanFilters{Tags: map[string]*string{
+			"cost-center,team": ptr.String("a=b,c"),
+			"Owner":            nil,
+		}},
+	}
+
+	assert.Equal(t,
+		lister.TagFilter{"cost-center,team": ptr.String("a=b,c"), "Owner": nil},
+		tagFilter(testCommand(t), p))
+	assert.Equal(t,
+		lister.TagFilter{"Environment": ptr.String("dev")},
+		tagFilter(testCommand(t, "--tags", "Environment=dev"), p))
+	assert.Equal(t, lister.TagFilter{}, tagFilter(testCommand(t), config.ScanProfile{}))
+}
+
+func TestDefaultScanProfile(t *testing.T) {
+	command := testCommand(t, "--config", writeTestConfig(t))
+
+	p, err := scanProfile(command)
+	assert.Nil(t, err)
+	defaults := profileFlags(p)
+
+	assert.Equal(t, "table", flagValue(command, "output", defaults))
+	assert.Equal(t, "all", flagValue(command, "regions", defaults))
+}
+
+func TestUnknownScanProfile(t *testing.T) {
+	_, err := scanProfile(testCommand(t, "--config", writeTestConfig(t), "--scan-profile", "weekly"))
+	assert.NotNil(t, err)
+
+	_, err = scanProfile(testCommand(t, "--config", filepath.Join(t.TempDir(), "missing.yaml")))
+	assert.NotNil(t, err)
+}
+
+func TestWithoutConfigFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	p, err := scanProfile(testCommand(t))
+	assert.Nil(t, err)
+	assert.Empty(t, profileFlags(p))
+}
@@ -0,0 +1,83 @@
+package ec2_tasks
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	conn "github.com/vcsomor/aws-resources/internal/aws_connector"
+	"github.com/vcsomor/aws-resources/internal/executor"
+	"time"
+)
+
+type listTask struct {
+	logger   *logrus.Entry
+	client   conn.EC2Client
+	pageSize int32
+}
+
+type ListResultEC2Data struct {
+	Arn        string
+	ID         string
+	LaunchTime *time.Time
+
+	InstanceType       string
+	State              string
+	ImageID            *string
+	VpcID              *string
+	SubnetID           *string
+	PrivateIPAddress   *string
+	PublicIPAddress    *string
+	IamInstanceProfile *string
+
+	Tags map[string]*string
+}
+
+type ListResult struct {
+	EC2Instances []ListResultEC2Data
+}
+
+var _ executor.Task[ListResult] = (*listTask)(nil)
+
+func NewListTask(
+	logger *logrus.Entry,
+	client conn.EC2Client,
+	pageSize int32,
+) executor.Task[ListResult] {
+	return &listTask{
+		logger:   logger,
+		client:   client,
+		pageSize: pageSize,
+	}
+}
+
+func (t *listTask) Execute(ctx context.Context) (ListResult, error) {
+	resources, err := t.client.List(ctx, conn.ListEC2Params{PageSize: t.pageSize})
+	if err != nil {
+		t.logger.WithError(err).
+			Error("unable to list resources")
+		return ListResult{}, err
+	}
+
+	var ec2Instances []ListResultEC2Data
+	for _, res := range resources {
+		ec2Instances = append(ec2Instances, ListResultEC2Data{
+			Arn:        res.Arn,
+			ID:         res.ID,
+			LaunchTime: res.LaunchTime,
+
+			InstanceType:       res.InstanceType,
+			State:              res.State,
+			ImageID:            res.ImageID,
+			VpcID:              res.VpcID,
+			SubnetID:           res.SubnetID,
+			PrivateIPAddress:   res.PrivateIPAddress,
+			PublicIPAddress:    res.PublicIPAddress,
+			IamInstanceProfile: res.IamInstanceProfile,
+
+			Tags: res.Tags,
+		})
+	}
+
+	t.logger.Debug("resources listed")
+
+	return ListResult{EC2Instances: ec2Instances}, nil
+}
@@ -0,0 +1,33 @@
+package lister
+
+// TagFilter keeps the resources having every tag of the filter, a nil value matches any value of the tag
+type TagFilter map[string]*string
+
+func (f TagFilter) Matches(r Result) bool {
+	tags := r.Tags()
+	for k, want := range f {
+		v, exist := tags[k]
+		if !exist {
+			return false
+		}
+		if want == nil {
+			continue
+		}
+		if v == nil || *v != *want {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter hands over the resources kept by the filter to the sink, an empty filter keeps every resource
+func Filter(f TagFilter, sink Sink) Sink {
+	if len(f) == 0 {
+		return sink
+	}
+	return func(r Result) {
+		if f.Matches(r) {
+			sink(r)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package lister
+
+import (
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTagFilter(t *testing.T) {
+	prod := Result{Properties: RDSData{Tags: map[string]*string{
+		"Environment": ptr.String("prod"),
+		"Owner":       ptr.String("team-a"),
+	}}}
+	dev := Result{Properties: EC2Data{Tags: map[string]*string{
+		"Environment": ptr.String("dev"),
+	}}}
+	untagged := Result{Properties: S3Data{}}
+
+	f := TagFilter{"Environment": ptr.String("prod"), "Owner": nil}
+	assert.True(t, f.Matches(prod))
+	assert.False(t, f.Matches(dev))
+	assert.False(t, f.Matches(untagged))
+
+	assert.True(t, TagFilter{"Environment": nil}.Matches(dev))
+	assert.True(t, TagFilter{}.Matches(untagged))
+}
+
+func TestFilter(t *testing.T) {
+	var kept []string
+	sink := Filter(TagFilter{"Owner": nil}, func(r Result) {
+		kept = append(kept, r.ID)
+	})
+
+	sink(Result{ID: "owned", Properties: RDSData{Tags: map[string]*string{"Owner": ptr.String("team-a")}}})
+	sink(Result{ID: "untagged", Properties: RDSData{}})
+	assert.Equal(t, []string{"owned"}, kept)
+}
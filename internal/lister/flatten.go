@@ -1,8 +1,12 @@
 package lister
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
+	"strconv"
 )
 
 const arrayTag = "ARRAY"
@@ -24,6 +28,107 @@ func (r *objectFlattener) Flatten(data map[string]any) map[string]any {
 	return flattenMap("", data)
 }
 
+// FlattenJSON flattens the JSON form of an object, so the keys are the JSON field names e.g.:
+// "properties.tags.Owner", the numbers are kept as json.Number
+func FlattenJSON(obj any) (map[string]any, error) {
+	generic, err := decodeJSON(obj)
+	if err != nil {
+		return nil, err
+	}
+	return flattenObject(generic)
+}
+
+// FlattenJSONRows flattens the JSON form of an object or of a list of objects into a row per object,
+// the null elements of the list are skipped
+func FlattenJSONRows(obj any) ([]map[string]any, error) {
+	generic, err := decodeJSON(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	list, isList := generic.([]any)
+	if !isList {
+		row, err := flattenObject(generic)
+		if row == nil {
+			return nil, err
+		}
+		return []map[string]any{row}, nil
+	}
+
+	var rows []map[string]any
+	for _, item := range list {
+		if item == nil {
+			continue
+		}
+		row, err := flattenObject(item)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// Columns returns the union of the keys of the flattened rows in sorted order
+func Columns(rows []map[string]any) []string {
+	seen := map[string]struct{}{}
+	var columns []string
+	for _, row := range rows {
+		for column := range row {
+			if _, exist := seen[column]; exist {
+				continue
+			}
+			seen[column] = struct{}{}
+			columns = append(columns, column)
+		}
+	}
+	slices.Sort(columns)
+	return columns
+}
+
+// FormatValue formats a flattened value as text, nil is an empty text
+func FormatValue(v any) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+// decodeJSON turns the object into generic JSON values, the numbers are kept as json.Number
+func decodeJSON(obj any) (any, error) {
+	raw, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic any
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err = dec.Decode(&generic); err != nil {
+		return nil, err
+	}
+	return generic, nil
+}
+
+func flattenObject(generic any) (map[string]any, error) {
+	switch v := generic.(type) {
+	case nil:
+		return nil, nil
+	case map[string]any:
+		return flattenMap("", v), nil
+	default:
+		return nil, fmt.Errorf("unsupported type %T, only objects can be flattened", generic)
+	}
+}
+
 func flattenSlice(root string, data []any) map[string]any {
 	if len(data) == 0 {
 		return map[string]any{
@@ -1,6 +1,7 @@
 package lister
 
 import (
+	"encoding/json"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -104,3 +105,69 @@ func TestFlatten(t *testing.T) {
 			},
 		}))
 }
+
+func TestFlattenJSON(t *testing.T) {
+	owner, engine := "team-a", "postgres"
+	flat, err := FlattenJSON(Result{
+		Arn:        "arn:aws:rds:eu-west-1:123456789012:db:my-db",
+		ID:         "my-db",
+		Properties: RDSData{Engine: &engine, Tags: map[string]*string{"Owner": &owner}},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "my-db", flat["id"])
+	assert.Equal(t, "postgres", flat["properties.engine"])
+	assert.Equal(t, "team-a", flat["properties.tags.Owner"])
+
+	flat, err = FlattenJSON(nil)
+	assert.Nil(t, err)
+	assert.Nil(t, flat)
+
+	_, err = FlattenJSON([]string{"a"})
+	assert.NotNil(t, err)
+}
+
+func TestFlattenJSONRows(t *testing.T) {
+	rows, err := FlattenJSONRows([]Result{
+		{Arn: "arn:aws:s3:::bucket-1", Properties: S3Data{Region: "eu-west-1"}},
+		{Arn: "arn:aws:s3:::bucket-2"},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, "eu-west-1", rows[0]["properties.region"])
+	assert.Equal(t, "arn:aws:s3:::bucket-2", rows[1]["arn"])
+
+	rows, err = FlattenJSONRows(Result{ID: "bucket-1"})
+	assert.Nil(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, "bucket-1", rows[0]["id"])
+
+	rows, err = FlattenJSONRows([]*Result{nil, {ID: "bucket-2"}, nil})
+	assert.Nil(t, err)
+	assert.Len(t, rows, 1)
+	assert.Equal(t, "bucket-2", rows[0]["id"])
+
+	rows, err = FlattenJSONRows(nil)
+	assert.Nil(t, err)
+	assert.Nil(t, rows)
+
+	_, err = FlattenJSONRows([]string{"a"})
+	assert.ErrorContains(t, err, "unsupported type string")
+}
+
+func TestFormatValue(t *testing.T) {
+	assert.Equal(t, "", FormatValue(nil))
+	assert.Equal(t, "text", FormatValue("text"))
+	assert.Equal(t, "10", FormatValue(json.Number("10")))
+	assert.Equal(t, "true", FormatValue(true))
+	assert.Equal(t, "1.5", FormatValue(1.5))
+}
+
+func TestColumns(t *testing.T) {
+	assert.Equal(t,
+		[]string{"arn", "id", "properties.region"},
+		Columns([]map[string]any{
+			{"id": "bucket-1", "arn": "arn:aws:s3:::bucket-1"},
+			{"properties.region": "eu-west-1", "arn": "arn:aws:s3:::bucket-2"},
+		}))
+	assert.Nil(t, Columns(nil))
+}
@@ -0,0 +1,68 @@
+package lister
+
+import (
+	"context"
+	conn "github.com/vcsomor/aws-resources/internal/aws_connector"
+	"github.com/vcsomor/aws-resources/internal/executor"
+	"github.com/vcsomor/aws-resources/internal/lister/ec2_tasks"
+)
+
+const operationDescribeInstances = "DescribeInstances"
+
+type ec2Collector struct {
+}
+
+var _ TaskCollector[ec2_tasks.ListResult] = (*ec2Collector)(nil)
+
+func (ec2Collector) Name() string {
+	return ec2ResourceType
+}
+
+func (ec2Collector) Global() bool {
+	return false
+}
+
+func (ec2Collector) NewClient(ctx context.Context, factory conn.ClientFactory, region *string) (any, error) {
+	return factory.EC2Client(ctx, region)
+}
+
+func (ec2Collector) NewTasks(ctx context.Context, env Environment, client any) []executor.Task[ec2_tasks.ListResult] {
+	return []executor.Task[ec2_tasks.ListResult]{
+		ec2_tasks.NewListTask(env.Logger, client.(conn.EC2Client), env.PageSize),
+	}
+}
+
+func (ec2Collector) Assemble(_ context.Context, env Environment, result TaskResult[ec2_tasks.ListResult]) []Result {
+	return assembleEC2TaskResult(result, env)
+}
+
+func assembleEC2TaskResult(r TaskResult[ec2_tasks.ListResult], env Environment) []Result {
+	if err := r.Error; err != nil {
+		env.Logger.WithError(err).
+			Error("error while fetching the EC2 instances")
+		env.RecordError(r.Region, "", operationDescribeInstances, err)
+		return nil
+	}
+
+	var results []Result
+	for _, ec2 := range r.Outcome.EC2Instances {
+		results = append(results, Result{
+			Arn:          ec2.Arn,
+			ID:           ec2.ID,
+			CreationTime: ec2.LaunchTime,
+			Properties: EC2Data{
+				InstanceType:       ec2.InstanceType,
+				State:              ec2.State,
+				ImageID:            ec2.ImageID,
+				VpcID:              ec2.VpcID,
+				SubnetID:           ec2.SubnetID,
+				PrivateIPAddress:   ec2.PrivateIPAddress,
+				PublicIPAddress:    ec2.PublicIPAddress,
+				IamInstanceProfile: ec2.IamInstanceProfile,
+
+				Tags: ec2.Tags,
+			},
+		})
+	}
+	return results
+}
@@ -2,158 +2,174 @@ package lister
 
 import (
 	"context"
-	"errors"
 	"fmt"
-	"github.com/sirupsen/logrus"
+	conn "github.com/vcsomor/aws-resources/internal/aws_connector"
 	"github.com/vcsomor/aws-resources/internal/executor"
 	"github.com/vcsomor/aws-resources/internal/lister/s3_tasks"
 	"slices"
 )
 
-func (l *taskBasedLister) listS3(ctx context.Context) []Result {
-	logger := l.logger.WithField(logKeyResourceType, s3ResourceType)
+const (
+	operationListBuckets       = "ListBuckets"
+	operationGetBucketLocation = "GetBucketLocation"
+	operationGetBucketTagging  = "GetBucketTagging"
+)
 
-	buckets, err := l.fetchAllS3Buckets(ctx, logger)
-	if err != nil {
+type s3Collector struct {
+}
+
+var _ TaskCollector[s3_tasks.ListTaskResult] = (*s3Collector)(nil)
+
+func (s3Collector) Name() string {
+	return s3ResourceType
+}
+
+func (s3Collector) Global() bool {
+	return true
+}
+
+func (s3Collector) NewClient(ctx context.Context, factory conn.ClientFactory, region *string) (any, error) {
+	return factory.S3Client(ctx, region)
+}
+
+func (s3Collector) NewTasks(ctx context.Context, env Environment, client any) []executor.Task[s3_tasks.ListTaskResult] {
+	return []executor.Task[s3_tasks.ListTaskResult]{
+		s3_tasks.NewListTask(env.Logger, client.(conn.S3Client)),
+	}
+}
+
+func (s3Collector) Assemble(ctx context.Context, env Environment, result TaskResult[s3_tasks.ListTaskResult]) []Result {
+	logger := env.Logger
+
+	if err := result.Error; err != nil {
 		logger.WithError(err).
 			Error("unable fetch all buckets")
+		env.RecordError("", "", operationListBuckets, err)
 		return nil
 	}
+	buckets := result.Outcome.Buckets
 
-	regionMappings, err := l.fetchAllS3BucketRegions(ctx, logger, buckets.Buckets)
+	client, err := env.ClientFactory.S3Client(ctx, nil)
 	if err != nil {
 		logger.WithError(err).
 			Error("unable fetch regions for buckets")
+		env.RecordError("", "", operationCreateClient, err)
 		return nil
 	}
 
-	tagMappings := l.fetchTagsForBuckets(ctx, logger, regionMappings)
-
-	return assembleResults(buckets.Buckets, regionMappings, tagMappings)
-}
+	var tasks []executor.Task[bucketDetails]
+	for _, b := range buckets {
+		tasks = append(tasks, &bucketTask{
+			env:    env,
+			client: client,
+			bucket: b.Name,
+		})
+	}
 
-func assembleResults(
-	buckets []s3_tasks.ListTaskBucketData,
-	regionMappings map[string]string,
-	tags map[string]map[string]*string,
-) []Result {
-	var result []Result
-
-	for _, bucket := range buckets {
-		bucketName := bucket.Name
-		if r, exist := regionMappings[bucketName]; exist {
-			result = append(result, anS3Result(bucket, r, tags[bucketName]))
+	// the buckets are emitted one by one as their tags arrive
+	for r := range executor.ExecuteAllAsCompleted(ctx, env.Executor, tasks) {
+		if res, ok := assembleBucket(env, buckets[r.Index], r.SynchronousResult); ok {
+			env.Emit(res)
 		}
 	}
+	return nil
+}
 
-	return result
+// bucketDetails is the location of a bucket and its tags if the bucket is in a listed region, the
+// failure of the tag fetching is kept along with the operation which failed
+type bucketDetails struct {
+	location s3_tasks.GetRegionResult
+	listed   bool
+	tags     map[string]*string
+
+	operation string
+	err       error
 }
 
-func (l *taskBasedLister) fetchAllS3Buckets(ctx context.Context, logger *logrus.Entry) (s3_tasks.ListTaskResult, error) {
-	client, err := l.clientFactory.S3Client(ctx, nil)
-	if err != nil {
-		return s3_tasks.ListTaskResult{},
-			errors.Join(errors.New("unable to create the client"), err)
-	}
+// bucketTask fetches the tags of a bucket as soon as its region is known, so a bucket does not wait
+// for the location of every other bucket
+type bucketTask struct {
+	env    Environment
+	client conn.S3Client
+	bucket string
+}
+
+var _ executor.Task[bucketDetails] = (*bucketTask)(nil)
 
-	taskResult, err := l.executor.Execute(s3_tasks.NewListTask(ctx, logger, client))
+func (t *bucketTask) Execute(ctx context.Context) (bucketDetails, error) {
+	location, err := s3_tasks.NewS3GetRegionTask(t.env.Logger, t.client, t.bucket).Execute(ctx)
 	if err != nil {
-		return s3_tasks.ListTaskResult{},
-			errors.Join(errors.New("unable to start listing task"), err)
+		return bucketDetails{}, err
 	}
 
-	buckets := taskResult.(s3_tasks.ListTaskResult)
-	if err = buckets.Error; err != nil {
-		return s3_tasks.ListTaskResult{},
-			errors.Join(errors.New("task error"), err)
+	details := bucketDetails{location: location}
+	if !regionFiler(location.Region, t.env.Regions) {
+		return details, nil
 	}
-	return buckets, nil
-}
+	details.listed = true
 
-func (l *taskBasedLister) fetchAllS3BucketRegions(
-	ctx context.Context,
-	logger *logrus.Entry,
-	buckets []s3_tasks.ListTaskBucketData,
-) (map[string]string, error) {
-	client, err := l.clientFactory.S3Client(ctx, nil)
+	region := location.Region
+	client, err := t.env.ClientFactory.S3Client(ctx, &region)
 	if err != nil {
-		return nil,
-			errors.Join(errors.New("unable to create the client"), err)
+		details.operation, details.err = operationCreateClient, err
+		return details, nil
 	}
 
-	var tasks []executor.Task
-	for _, b := range buckets {
-		tasks = append(tasks, s3_tasks.NewS3GetRegionTask(ctx, logger, client, b.Name))
+	tags, err := s3_tasks.NewS3GetTagsTask(t.env.Logger, client, t.bucket).Execute(ctx)
+	if err != nil {
+		details.operation, details.err = operationGetBucketTagging, err
+		return details, nil
 	}
+	details.tags = tags.Tags
+	return details, nil
+}
 
-	result := map[string]string{}
-	for _, execResult := range l.executor.ExecuteAll(tasks) {
-		if err = execResult.Error; err != nil {
-			logger.WithError(err).
-				Error("error while fetching the region")
-			continue
-		}
+// assembleBucket builds the result of a bucket, the buckets outside of the listed regions or with an
+// unknown location are left out
+func assembleBucket(
+	env Environment,
+	bucket s3_tasks.ListTaskBucketData,
+	r executor.SynchronousResult[bucketDetails],
+) (Result, bool) {
+	logger := env.Logger
 
-		taskResult := execResult.Outcome.(s3_tasks.GetRegionResult)
-		if err = taskResult.Error; err != nil {
-			logger.WithError(err).
-				Error("region fetch error")
-			continue
-		}
-
-		if region := taskResult.Region; regionFiler(region, l.regions) {
-			result[taskResult.BucketName] = region
-		}
+	if err := r.Error; err != nil {
+		logger.WithError(err).
+			Error("error while fetching the region")
+		env.RecordError("", bucket.Name, operationGetBucketLocation, err)
+		return Result{}, false
 	}
-	return result, nil
-}
-
-func (l *taskBasedLister) fetchTagsForBuckets(
-	ctx context.Context,
-	logger *logrus.Entry,
-	mappings map[string]string,
-) map[string]map[string]*string {
-	tags := map[string]map[string]*string{}
-
-	var tasks []executor.Task
-	for name, region := range mappings {
-		r := region // avoid taking the address of the auto var
-		client, errClient := l.clientFactory.S3Client(ctx, &r)
-		if errClient != nil {
-			logger.WithError(errClient).
-				Error("client build error")
-			continue
-		}
 
-		tasks = append(tasks, s3_tasks.NewS3GetTagsTask(ctx, logger, client, name))
+	details := r.Outcome
+	if !details.listed {
+		return Result{}, false
 	}
 
-	for _, execResult := range l.executor.ExecuteAll(tasks) {
-		if err := execResult.Error; err != nil {
+	res := anS3Result(bucket, details.location, details.tags)
+	if err := details.err; err != nil {
+		if details.operation == operationGetBucketTagging && conn.ClassifyError(err) == conn.ErrorClassAccessDenied {
+			res.Warnings = append(res.Warnings, "tags are not readable: access denied")
+		} else {
 			logger.WithError(err).
 				Error("error while fetching the bucket tags")
-			continue
+			env.RecordError(details.location.Region, bucket.Name, details.operation, err)
 		}
-
-		taskResult := execResult.Outcome.(s3_tasks.GetTagsResult)
-		if err := taskResult.Error; err != nil {
-			logger.WithError(err).
-				Error("tags fetch error")
-			continue
-		}
-
-		tags[taskResult.BucketName] = taskResult.Tags
 	}
-	return tags
+	return res, true
 }
 
-func anS3Result(baseData s3_tasks.ListTaskBucketData, region string, tags map[string]*string) Result {
+func anS3Result(
+	baseData s3_tasks.ListTaskBucketData,
+	location s3_tasks.GetRegionResult,
+	tags map[string]*string,
+) Result {
 	return Result{
 		Arn:          fmt.Sprintf("arn:aws:s3:::%s", baseData.Name),
 		ID:           baseData.Name,
 		CreationTime: baseData.Created,
 		Properties: S3Data{
-			LocationConstraint: region,
+			LocationConstraint: location.LocationConstraint,
+			Region:             location.Region,
 			Tags:               tags,
 		},
 	}
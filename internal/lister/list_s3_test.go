@@ -0,0 +1,186 @@
+package lister
+
+import (
+	"context"
+	"errors"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/ptr"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	conn "github.com/vcsomor/aws-resources/internal/aws_connector"
+	"github.com/vcsomor/aws-resources/internal/executor"
+	"github.com/vcsomor/aws-resources/internal/lister/s3_tasks"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRegionFilter(t *testing.T) {
+	regions := []string{"us-east-1", "eu-west-1"}
+
+	assert.True(t, regionFiler("us-east-1", regions))
+	assert.False(t, regionFiler("", regions))
+	assert.False(t, regionFiler("us-east-2", regions))
+}
+
+type fakeS3Client struct {
+	buckets   []string
+	locations map[string]string
+	tags      map[string]map[string]*string
+	errors    map[string]error
+
+	// the location of the blocked bucket is only returned once the tags of another bucket were fetched
+	blocked     string
+	tagsFetched chan struct{}
+	once        sync.Once
+}
+
+func (c *fakeS3Client) List(_ context.Context, _ conn.ListS3Params) ([]conn.ListS3Result, error) {
+	var res []conn.ListS3Result
+	for _, b := range c.buckets {
+		res = append(res, conn.ListS3Result{Name: b})
+	}
+	return res, nil
+}
+
+func (c *fakeS3Client) GetRegion(ctx context.Context, p conn.GetS3RegionParams) (conn.GetS3RegionResult, error) {
+	name := p.BucketName()
+	if name == c.blocked {
+		select {
+		case <-c.tagsFetched:
+		case <-ctx.Done():
+			return conn.GetS3RegionResult{}, ctx.Err()
+		}
+	}
+	if err, exist := c.errors["location/"+name]; exist {
+		return conn.GetS3RegionResult{}, err
+	}
+	return conn.GetS3RegionResult{Name: name, Region: c.locations[name]}, nil
+}
+
+func (c *fakeS3Client) GetTags(_ context.Context, p conn.GetS3BucketTagsParams) (conn.GetS3BucketTagsResult, error) {
+	name := p.BucketName()
+	if c.tagsFetched != nil {
+		c.once.Do(func() { close(c.tagsFetched) })
+	}
+	if err, exist := c.errors["tags/"+name]; exist {
+		return conn.GetS3BucketTagsResult{}, err
+	}
+	return conn.GetS3BucketTagsResult{Name: name, Tags: c.tags[name]}, nil
+}
+
+type fakeClientFactory struct {
+	conn.ClientFactory
+	s3 *fakeS3Client
+}
+
+func (f *fakeClientFactory) S3Client(_ context.Context, _ *string) (conn.S3Client, error) {
+	return f.s3, nil
+}
+
+func listS3(t *testing.T, client *fakeS3Client, regions []string) ([]Result, Report) {
+	p, err := executor.NewThreadpool(4)
+	assert.Nil(t, err)
+	defer p.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	return NewLister().
+		Dependencies(
+			WithClientFactory(&fakeClientFactory{s3: client}),
+			WithExecutor(executor.NewSynchronousExecutor(p)),
+			WithLogger(logrus.New()),
+			WithRegistry(NewRegistry(NewTaskCollector[s3_tasks.ListTaskResult](s3Collector{}))),
+		).
+		Parameters(
+			WithRegions(regions),
+			WithResources([]string{"s3"}),
+		).
+		Build().
+		List(ctx)
+}
+
+func TestListS3(t *testing.T) {
+	res, report := listS3(t,
+		&fakeS3Client{
+			buckets: []string{"virginia", "legacy", "filtered", "broken", "throttled"},
+			locations: map[string]string{
+				"virginia":  "us-east-1",
+				"legacy":    "eu-west-1",
+				"filtered":  "us-east-2",
+				"throttled": "us-east-1",
+			},
+			tags: map[string]map[string]*string{
+				"virginia": {"Owner": ptr.String("finance")},
+			},
+			errors: map[string]error{
+				"location/broken": errors.New("connection reset"),
+				"tags/legacy":     &smithy.GenericAPIError{Code: "AccessDenied"},
+				"tags/throttled":  &smithy.GenericAPIError{Code: "SlowDown"},
+			},
+		},
+		[]string{"us-east-1", "eu-west-1"})
+
+	assert.ElementsMatch(t,
+		[]Result{
+			{
+				Arn:  "arn:aws:s3:::virginia",
+				ID:   "virginia",
+				Type: "s3",
+				Properties: S3Data{
+					Region: "us-east-1",
+					Tags:   map[string]*string{"Owner": ptr.String("finance")},
+				},
+			},
+			{
+				Arn:        "arn:aws:s3:::legacy",
+				ID:         "legacy",
+				Properties: S3Data{Region: "eu-west-1"},
+				Warnings:   []string{"tags are not readable: access denied"},
+				Type:       "s3",
+			},
+			{
+				Arn:        "arn:aws:s3:::throttled",
+				ID:         "throttled",
+				Properties: S3Data{Region: "us-east-1"},
+				Type:       "s3",
+			},
+		},
+		res)
+
+	assert.ElementsMatch(t,
+		[]ResourceError{
+			{
+				ResourceType: "s3",
+				Resource:     "broken",
+				Operation:    "GetBucketLocation",
+				Class:        "other",
+				Message:      "connection reset",
+			},
+			{
+				ResourceType: "s3",
+				Region:       "us-east-1",
+				Resource:     "throttled",
+				Operation:    "GetBucketTagging",
+				Class:        "throttled",
+				Message:      "api error SlowDown: ",
+			},
+		},
+		report.Errors)
+}
+
+func TestS3TagsDoNotWaitForEveryRegion(t *testing.T) {
+	// the tags of "fast" have to be fetched while the location of "slow" is still pending
+	res, report := listS3(t,
+		&fakeS3Client{
+			buckets:     []string{"slow", "fast"},
+			locations:   map[string]string{"slow": "us-east-1", "fast": "us-east-1"},
+			blocked:     "slow",
+			tagsFetched: make(chan struct{}),
+		},
+		[]string{"us-east-1"})
+
+	assert.Empty(t, report.Errors)
+	assert.Len(t, res, 2)
+}
@@ -5,34 +5,86 @@ import (
 	"github.com/sirupsen/logrus"
 	conn "github.com/vcsomor/aws-resources/internal/aws_connector"
 	"github.com/vcsomor/aws-resources/internal/executor"
-	"slices"
+	"sync"
 )
 
 type Lister interface {
-	List(ctx context.Context) []Result
+	// List returns every resource once the listing has finished
+	List(ctx context.Context) ([]Result, Report)
+
+	// Stream hands every resource over to the sink as soon as it is assembled, the resource types are
+	// listed at the same time so the sink is called concurrently
+	Stream(ctx context.Context, sink Sink) Report
 }
 
 type taskBasedLister struct {
 	clientFactory conn.ClientFactory
 	executor      executor.SynchronousExecutor
 	logger        *logrus.Logger
+	registry      *Registry
 
 	regions   []string
 	resources []string
+	pageSize  int32
 }
 
 var _ Lister = (*taskBasedLister)(nil)
 
-func (l *taskBasedLister) List(ctx context.Context) (res []Result) {
-	if slices.Contains(l.resources, "s3") {
-		res = append(res, l.listS3(ctx)...)
-	}
-	if slices.Contains(l.resources, "rds") {
-		res = append(res, l.listRDS(ctx)...)
+func (l *taskBasedLister) List(ctx context.Context) ([]Result, Report) {
+	sink := &collectingSink{}
+	report := l.Stream(ctx, sink.Put)
+	return sink.Results(), report
+}
+
+func (l *taskBasedLister) Stream(ctx context.Context, sink Sink) Report {
+	recorder := NewRecorder()
+	counter := NewCounter()
+	sink = Tee(counter.Put, sink)
+
+	// the collectors only wait for their tasks, the tasks of every resource type share the threadpool
+	var wg sync.WaitGroup
+	for _, resource := range l.resources {
+		c, exist := l.registry.Get(resource)
+		if !exist {
+			l.logger.WithField(logKeyResourceType, resource).
+				Warn("unknown resource type")
+			continue
+		}
+
+		wg.Add(1)
+		go func(c Collector) {
+			defer wg.Done()
+			l.collect(ctx, c, recorder, sink)
+		}(c)
 	}
+	wg.Wait()
 
-	l.logger.WithField(logKeyResourceCount, len(res)).
+	report := recorder.Report()
+	l.logger.WithField(logKeyResourceCount, totalCount(counter.Counts())).
+		WithField(logKeyErrorCount, len(report.Errors)).
 		Debug("resources listed")
 
-	return res
+	return report
+}
+
+func (l *taskBasedLister) collect(ctx context.Context, c Collector, recorder *Recorder, sink Sink) {
+	env := Environment{
+		ClientFactory: l.clientFactory,
+		Executor:      l.executor,
+		Logger:        l.logger.WithField(logKeyResourceType, c.Name()),
+		Recorder:      recorder,
+		ResourceType:  c.Name(),
+		Regions:       l.regions,
+		PageSize:      l.pageSize,
+		Sink:          sink,
+	}
+
+	c.Collect(ctx, env)
+}
+
+func totalCount(counts map[string]int) (total int) {
+	for _, n := range counts {
+		total += n
+	}
+	return total
 }
@@ -10,6 +10,7 @@ type Dependencies struct {
 	clientFactory conn.ClientFactory
 	executor      executor.SynchronousExecutor
 	logger        *logrus.Logger
+	registry      *Registry
 }
 
 type DependencyFn func(d *Dependencies)
@@ -32,9 +33,16 @@ func WithLogger(l *logrus.Logger) DependencyFn {
 	}
 }
 
+func WithRegistry(r *Registry) DependencyFn {
+	return func(d *Dependencies) {
+		d.registry = r
+	}
+}
+
 type Parameters struct {
 	regions   []string
 	resources []string
+	pageSize  int32
 }
 
 type ParametersFn func(p *Parameters)
@@ -51,6 +59,14 @@ func WithResources(resources []string) ParametersFn {
 	}
 }
 
+// WithPageSize sets the number of resources requested per page by the paginated listings, zero uses
+// the default of the service
+func WithPageSize(size int32) ParametersFn {
+	return func(p *Parameters) {
+		p.pageSize = size
+	}
+}
+
 type Builder struct {
 	depFns   []DependencyFn
 	paramFns []ParametersFn
@@ -76,6 +92,10 @@ func (b *Builder) Build() Lister {
 		fnc(&deps)
 	}
 
+	if deps.registry == nil {
+		deps.registry = DefaultRegistry()
+	}
+
 	var params Parameters
 	for _, fnc := range b.paramFns {
 		fnc(&params)
@@ -85,8 +105,10 @@ func (b *Builder) Build() Lister {
 		clientFactory: deps.clientFactory,
 		executor:      deps.executor,
 		logger:        deps.logger,
+		registry:      deps.registry,
 
 		regions:   params.regions,
 		resources: params.resources,
+		pageSize:  params.pageSize,
 	}
 }
@@ -0,0 +1,114 @@
+package lister
+
+import (
+	conn "github.com/vcsomor/aws-resources/internal/aws_connector"
+	"github.com/vcsomor/aws-resources/internal/version"
+	"time"
+)
+
+// Manifest describes a listing run so the archived outputs are self-describing
+type Manifest struct {
+	Account   string `json:"account"`
+	CallerArn string `json:"callerArn"`
+
+	// AWSProfile and AssumedRole tell where the credentials came from, the CallerArn is the session of
+	// the assumed role
+	AWSProfile  string       `json:"awsProfile,omitempty"`
+	AssumedRole *AssumedRole `json:"assumedRole,omitempty"`
+
+	Regions   []string `json:"regions"`
+	Resources []string `json:"resources"`
+
+	StartedAt  time.Time      `json:"startedAt"`
+	FinishedAt time.Time      `json:"finishedAt"`
+	Counts     map[string]int `json:"counts"`
+
+	// Interrupted runs were canceled before every task finished, their outputs are partial
+	Interrupted bool `json:"interrupted"`
+
+	// RateLimitWaits tells how long the requests waited for the --rate-limit per service and region
+	RateLimitWaits []conn.RateLimitWait `json:"rateLimitWaits,omitempty"`
+
+	Build BuildInfo `json:"build"`
+}
+
+// AssumedRole is the role the inventory was performed with, the external ID itself is not recorded
+type AssumedRole struct {
+	RoleArn        string `json:"roleArn"`
+	SessionName    string `json:"sessionName"`
+	ExternalIDUsed bool   `json:"externalIdUsed"`
+	MFASerial      string `json:"mfaSerial,omitempty"`
+}
+
+type BuildInfo struct {
+	Version   string `json:"version"`
+	GitCommit string `json:"gitCommit"`
+	BuildDate string `json:"buildDate"`
+	GoVersion string `json:"goVersion"`
+	OsArch    string `json:"osArch"`
+}
+
+type ManifestParams struct {
+	Identity   conn.CallerIdentityResult
+	AWSProfile string
+	AssumeRole *conn.AssumeRole
+	Regions    []string
+	Resources  []string
+	StartedAt  time.Time
+	FinishedAt time.Time
+
+	Interrupted    bool
+	RateLimitWaits []conn.RateLimitWait
+}
+
+// NewManifest builds the manifest of a run from the resource counts per type (see Counter), every
+// requested resource type gets a count even if nothing was found
+func NewManifest(p ManifestParams, resourceCounts map[string]int) Manifest {
+	counts := map[string]int{}
+	for _, r := range p.Resources {
+		counts[r] = 0
+	}
+	for t, n := range resourceCounts {
+		counts[t] += n
+	}
+
+	var assumed *AssumedRole
+	if r := p.AssumeRole; r != nil {
+		assumed = &AssumedRole{
+			RoleArn:        r.RoleArn,
+			SessionName:    r.SessionName,
+			ExternalIDUsed: r.ExternalID != "",
+			MFASerial:      r.MFASerial,
+		}
+	}
+
+	return Manifest{
+		Account:   p.Identity.Account,
+		CallerArn: p.Identity.Arn,
+
+		AWSProfile:  p.AWSProfile,
+		AssumedRole: assumed,
+
+		Regions:   append([]string{}, p.Regions...),
+		Resources: append([]string{}, p.Resources...),
+
+		StartedAt:  p.StartedAt.UTC(),
+		FinishedAt: p.FinishedAt.UTC(),
+		Counts:     counts,
+
+		Interrupted:    p.Interrupted,
+		RateLimitWaits: p.RateLimitWaits,
+
+		Build: currentBuildInfo(),
+	}
+}
+
+func currentBuildInfo() BuildInfo {
+	return BuildInfo{
+		Version:   version.Version,
+		GitCommit: version.GitCommit,
+		BuildDate: version.BuildDate,
+		GoVersion: version.GoVersion,
+		OsArch:    version.OsArch,
+	}
+}
@@ -0,0 +1,75 @@
+package lister
+
+import (
+	"github.com/stretchr/testify/assert"
+	conn "github.com/vcsomor/aws-resources/internal/aws_connector"
+	"github.com/vcsomor/aws-resources/internal/version"
+	"testing"
+	"time"
+)
+
+func TestNewManifest(t *testing.T) {
+	started := time.Date(2024, 3, 1, 10, 0, 0, 0, time.FixedZone("CET", 3600))
+	finished := started.Add(90 * time.Second)
+
+	m := NewManifest(ManifestParams{
+		Identity: conn.CallerIdentityResult{
+			Account: "123456789012",
+			Arn:     "arn:aws:iam::123456789012:user/scanner",
+		},
+		Regions:    []string{"eu-west-1", "eu-west-3"},
+		Resources:  []string{"s3", "rds", "ec2"},
+		StartedAt:  started,
+		FinishedAt: finished,
+	}, countResources([]Result{
+		{Arn: "arn:aws:s3:::bucket-1", Type: "s3"},
+		{Arn: "arn:aws:s3:::bucket-2", Type: "s3"},
+		{Arn: "arn:aws:ec2:eu-west-1:123456789012:instance/i-1", Type: "ec2"},
+	}))
+
+	assert.Equal(t, "123456789012", m.Account)
+	assert.Equal(t, "arn:aws:iam::123456789012:user/scanner", m.CallerArn)
+	assert.Equal(t, []string{"eu-west-1", "eu-west-3"}, m.Regions)
+	assert.Equal(t, []string{"s3", "rds", "ec2"}, m.Resources)
+	assert.Equal(t, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC), m.StartedAt)
+	assert.Equal(t, time.Date(2024, 3, 1, 9, 1, 30, 0, time.UTC), m.FinishedAt)
+	assert.Equal(t, map[string]int{"s3": 2, "rds": 0, "ec2": 1}, m.Counts)
+	assert.Equal(t, version.Version, m.Build.Version)
+	assert.Equal(t, version.GoVersion, m.Build.GoVersion)
+	assert.Empty(t, m.AWSProfile)
+	assert.Nil(t, m.AssumedRole)
+}
+
+func TestManifestAssumedRole(t *testing.T) {
+	m := NewManifest(ManifestParams{
+		Identity: conn.CallerIdentityResult{
+			Account: "123456789012",
+			Arn:     "arn:aws:sts::123456789012:assumed-role/inventory/nightly",
+		},
+		AWSProfile: "audit",
+		AssumeRole: &conn.AssumeRole{
+			RoleArn:     "arn:aws:iam::123456789012:role/inventory",
+			ExternalID:  "secret-ish",
+			SessionName: "nightly",
+			MFAToken:    "123456",
+		},
+	}, nil)
+
+	assert.Equal(t, "arn:aws:sts::123456789012:assumed-role/inventory/nightly", m.CallerArn)
+	assert.Equal(t, "audit", m.AWSProfile)
+	assert.Equal(t,
+		&AssumedRole{
+			RoleArn:        "arn:aws:iam::123456789012:role/inventory",
+			SessionName:    "nightly",
+			ExternalIDUsed: true,
+		},
+		m.AssumedRole)
+}
+
+func countResources(res []Result) map[string]int {
+	c := NewCounter()
+	for _, r := range res {
+		c.Put(r)
+	}
+	return c.Counts()
+}
@@ -9,9 +9,9 @@ import (
 )
 
 type listTask struct {
-	ctx    context.Context
-	logger *logrus.Entry
-	client conn.RDSClient
+	logger   *logrus.Entry
+	client   conn.RDSClient
+	pageSize int32
 }
 
 type ListResultRDSData struct {
@@ -34,29 +34,28 @@ type ListResultRDSData struct {
 
 type ListResult struct {
 	RDSInstances []ListResultRDSData
-	Error        error
 }
 
-var _ executor.Task = (*listTask)(nil)
+var _ executor.Task[ListResult] = (*listTask)(nil)
 
 func NewListTask(
-	ctx context.Context,
 	logger *logrus.Entry,
 	client conn.RDSClient,
-) executor.Task {
+	pageSize int32,
+) executor.Task[ListResult] {
 	return &listTask{
-		ctx:    ctx,
-		logger: logger,
-		client: client,
+		logger:   logger,
+		client:   client,
+		pageSize: pageSize,
 	}
 }
 
-func (t *listTask) Execute() any {
-	resources, err := t.client.List(t.ctx, conn.ListRDSParams{})
+func (t *listTask) Execute(ctx context.Context) (ListResult, error) {
+	resources, err := t.client.List(ctx, conn.ListRDSParams{PageSize: t.pageSize})
 	if err != nil {
 		t.logger.WithError(err).
 			Error("unable to list resources")
-		return ListResult{Error: err}
+		return ListResult{}, err
 	}
 
 	var rdsInstances []ListResultRDSData
@@ -82,5 +81,5 @@ func (t *listTask) Execute() any {
 
 	t.logger.Debug("resources listed")
 
-	return ListResult{RDSInstances: rdsInstances}
+	return ListResult{RDSInstances: rdsInstances}, nil
 }
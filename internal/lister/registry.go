@@ -0,0 +1,139 @@
+package lister
+
+import (
+	"context"
+	"github.com/sirupsen/logrus"
+	conn "github.com/vcsomor/aws-resources/internal/aws_connector"
+	"github.com/vcsomor/aws-resources/internal/executor"
+	"github.com/vcsomor/aws-resources/internal/lister/ec2_tasks"
+	"github.com/vcsomor/aws-resources/internal/lister/rds_tasks"
+	"github.com/vcsomor/aws-resources/internal/lister/s3_tasks"
+	"slices"
+)
+
+// Environment is what the lister hands over to a Collector
+type Environment struct {
+	ClientFactory conn.ClientFactory
+	Executor      executor.SynchronousExecutor
+	Logger        *logrus.Entry
+	Recorder      *Recorder
+	ResourceType  string
+	Regions       []string
+	PageSize      int32
+	Sink          Sink
+}
+
+// Emit streams the assembled resources to the sink of the run, the resources get the type of the collector
+func (e Environment) Emit(results ...Result) {
+	if e.Sink == nil {
+		return
+	}
+	for _, r := range results {
+		r.Type = e.ResourceType
+		e.Sink(r)
+	}
+}
+
+// RecordError adds a failed operation to the report of the run, the region and the resource are optional
+func (e Environment) RecordError(region, resource, operation string, err error) {
+	e.Recorder.Record(newResourceError(e.ResourceType, region, resource, operation, err))
+}
+
+// Collector describes a resource type the lister is able to list.
+type Collector interface {
+	// Name is the resource type as used in the --resources argument e.g.: "s3"
+	Name() string
+
+	// Collect lists the resources of the type in the regions of the environment and emits them
+	// through Environment.Emit as soon as they are assembled
+	Collect(ctx context.Context, env Environment)
+}
+
+// TaskResult is the outcome of a task built by TaskCollector.NewTasks, the Region is empty for the default region
+type TaskResult[T any] struct {
+	executor.SynchronousResult[T]
+	Region string
+}
+
+// TaskCollector lists a resource type with tasks running on a client per region, the tasks return T.
+// NewTaskCollector turns it into a Collector.
+type TaskCollector[T any] interface {
+	// Name is the resource type as used in the --resources argument e.g.: "s3"
+	Name() string
+
+	// Global resource types are listed once through the default region instead of once per region
+	Global() bool
+
+	// NewClient creates the client used by the tasks, the region is nil for the default region
+	NewClient(ctx context.Context, factory conn.ClientFactory, region *string) (any, error)
+
+	// NewTasks builds the listing tasks using a client created by NewClient
+	NewTasks(ctx context.Context, env Environment, client any) []executor.Task[T]
+
+	// Assemble builds the results from the outcome of a task built by NewTasks, it is called for every
+	// task as soon as it completes
+	Assemble(ctx context.Context, env Environment, result TaskResult[T]) []Result
+}
+
+type Registry struct {
+	collectors []Collector
+}
+
+var defaultRegistry = NewRegistry(
+	NewTaskCollector[s3_tasks.ListTaskResult](s3Collector{}),
+	NewTaskCollector[rds_tasks.ListResult](rdsCollector{}),
+	NewTaskCollector[ec2_tasks.ListResult](ec2Collector{}),
+)
+
+func NewRegistry(collectors ...Collector) *Registry {
+	r := &Registry{}
+	for _, c := range collectors {
+		r.Register(c)
+	}
+	return r
+}
+
+// DefaultRegistry returns the registry used by the lister unless WithRegistry is given
+func DefaultRegistry() *Registry {
+	return defaultRegistry
+}
+
+// Register adds a collector to the default registry, it should be called from an init function
+func Register(c Collector) {
+	defaultRegistry.Register(c)
+}
+
+// ResourceTypes returns the names of the resource types in the default registry
+func ResourceTypes() []string {
+	return defaultRegistry.Names()
+}
+
+// Register adds the collector or replaces the one registered with the same name
+func (r *Registry) Register(c Collector) {
+	idx := slices.IndexFunc(r.collectors, func(registered Collector) bool {
+		return registered.Name() == c.Name()
+	})
+	if idx >= 0 {
+		r.collectors[idx] = c
+		return
+	}
+	r.collectors = append(r.collectors, c)
+}
+
+// Names returns the names of the collectors in registration order
+func (r *Registry) Names() []string {
+	var names []string
+	for _, c := range r.collectors {
+		names = append(names, c.Name())
+	}
+	return names
+}
+
+func (r *Registry) Get(name string) (Collector, bool) {
+	for _, c := range r.collectors {
+		if c.Name() == name {
+			return c, true
+		}
+	}
+	return nil, false
+}
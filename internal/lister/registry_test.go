@@ -0,0 +1,251 @@
+package lister
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	conn "github.com/vcsomor/aws-resources/internal/aws_connector"
+	"github.com/vcsomor/aws-resources/internal/executor"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeCollector struct {
+	name          string
+	global        bool
+	failingRegion string
+
+	// every task waits for the others to start, which only happens if they run at the same time
+	meet *sync.WaitGroup
+}
+
+var _ TaskCollector[string] = (*fakeCollector)(nil)
+
+type fakeTask struct {
+	region string
+	meet   *sync.WaitGroup
+}
+
+func (t *fakeTask) Execute(ctx context.Context) (string, error) {
+	if t.meet != nil {
+		t.meet.Done()
+		met := make(chan struct{})
+		go func() {
+			t.meet.Wait()
+			close(met)
+		}()
+
+		select {
+		case <-met:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+	return t.region, nil
+}
+
+func (c fakeCollector) Name() string {
+	return c.name
+}
+
+func (c fakeCollector) Global() bool {
+	return c.global
+}
+
+func (c fakeCollector) NewClient(_ context.Context, _ conn.ClientFactory, region *string) (any, error) {
+	if region == nil {
+		return "default", nil
+	}
+	if *region == c.failingRegion {
+		return nil, errors.New("no credentials")
+	}
+	return *region, nil
+}
+
+func (c fakeCollector) NewTasks(_ context.Context, _ Environment, client any) []executor.Task[string] {
+	return []executor.Task[string]{&fakeTask{region: client.(string), meet: c.meet}}
+}
+
+func (c fakeCollector) Assemble(_ context.Context, env Environment, r TaskResult[string]) []Result {
+	region := r.Outcome
+	if region == "empty-region" {
+		env.RecordError(r.Region, "", "ListThings", errors.New("throttled"))
+		return nil
+	}
+	return []Result{
+		{
+			Arn: fmt.Sprintf("arn:aws:%s:%s:123456789012:thing/x", c.name, region),
+			ID:  region,
+		},
+	}
+}
+
+func newFakeCollector(c fakeCollector) Collector {
+	return NewTaskCollector[string](c)
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry(newFakeCollector(fakeCollector{name: "a"}), newFakeCollector(fakeCollector{name: "b"}))
+	assert.Equal(t, []string{"a", "b"}, r.Names())
+
+	r.Register(newFakeCollector(fakeCollector{name: "a", global: true}))
+	r.Register(newFakeCollector(fakeCollector{name: "c"}))
+	assert.Equal(t, []string{"a", "b", "c"}, r.Names())
+
+	c, exist := r.Get("a")
+	assert.True(t, exist)
+	assert.Equal(t, newFakeCollector(fakeCollector{name: "a", global: true}), c)
+
+	_, exist = r.Get("d")
+	assert.False(t, exist)
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	assert.Equal(t, []string{"s3", "rds", "ec2"}, ResourceTypes())
+}
+
+func TestListWithRegisteredCollectors(t *testing.T) {
+	p, err := executor.NewThreadpool(2)
+	assert.Nil(t, err)
+	defer p.Shutdown()
+
+	res, report := NewLister().
+		Dependencies(
+			WithExecutor(executor.NewSynchronousExecutor(p)),
+			WithLogger(logrus.New()),
+			WithRegistry(NewRegistry(
+				newFakeCollector(fakeCollector{name: "regional"}),
+				newFakeCollector(fakeCollector{name: "global", global: true}),
+			)),
+		).
+		Parameters(
+			WithRegions([]string{"eu-west-1", "us-east-1"}),
+			WithResources([]string{"global", "unknown", "regional"}),
+		).
+		Build().
+		List(context.Background())
+
+	var ids []string
+	for _, r := range res {
+		ids = append(ids, r.ResourceType()+"/"+r.ID)
+	}
+	// the resources are listed in the order the tasks complete
+	assert.ElementsMatch(t,
+		[]string{
+			"global/default",
+			"regional/eu-west-1",
+			"regional/us-east-1",
+		},
+		ids)
+	assert.Equal(t, Report{Errors: []ResourceError{}}, report)
+}
+
+func TestListReport(t *testing.T) {
+	p, err := executor.NewThreadpool(2)
+	assert.Nil(t, err)
+	defer p.Shutdown()
+
+	res, report := NewLister().
+		Dependencies(
+			WithExecutor(executor.NewSynchronousExecutor(p)),
+			WithLogger(logrus.New()),
+			WithRegistry(NewRegistry(
+				newFakeCollector(fakeCollector{name: "regional", failingRegion: "eu-west-3"}),
+			)),
+		).
+		Parameters(
+			WithRegions([]string{"eu-west-1", "eu-west-3", "empty-region"}),
+			WithResources([]string{"regional"}),
+		).
+		Build().
+		List(context.Background())
+
+	assert.Len(t, res, 1)
+	assert.Equal(t,
+		Report{
+			Errors: []ResourceError{
+				{
+					ResourceType: "regional",
+					Region:       "eu-west-3",
+					Operation:    "CreateClient",
+					Class:        "other",
+					Message:      "no credentials",
+				},
+				{
+					ResourceType: "regional",
+					Region:       "empty-region",
+					Operation:    "ListThings",
+					Class:        "other",
+					Message:      "throttled",
+				},
+			},
+		},
+		report)
+}
+
+func TestStream(t *testing.T) {
+	p, err := executor.NewThreadpool(2)
+	assert.Nil(t, err)
+	defer p.Shutdown()
+
+	var mu sync.Mutex
+	var streamed []string
+	report := NewLister().
+		Dependencies(
+			WithExecutor(executor.NewSynchronousExecutor(p)),
+			WithLogger(logrus.New()),
+			WithRegistry(NewRegistry(
+				newFakeCollector(fakeCollector{name: "regional"}),
+				newFakeCollector(fakeCollector{name: "global", global: true}),
+			)),
+		).
+		Parameters(
+			WithRegions([]string{"eu-west-1", "us-east-1"}),
+			WithResources([]string{"global", "regional"}),
+		).
+		Build().
+		Stream(context.Background(), func(r Result) {
+			// the resource types are listed at the same time
+			mu.Lock()
+			defer mu.Unlock()
+			streamed = append(streamed, r.ResourceType()+"/"+r.ID)
+		})
+
+	assert.ElementsMatch(t, []string{"global/default", "regional/eu-west-1", "regional/us-east-1"}, streamed)
+	assert.Empty(t, report.Errors)
+}
+
+func TestResourceTypesListedConcurrently(t *testing.T) {
+	p, err := executor.NewThreadpool(3)
+	assert.Nil(t, err)
+	defer p.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// a listing running the resource types one after the other would time out
+	meet := &sync.WaitGroup{}
+	meet.Add(3)
+	res, report := NewLister().
+		Dependencies(
+			WithExecutor(executor.NewSynchronousExecutor(p)),
+			WithLogger(logrus.New()),
+			WithRegistry(NewRegistry(
+				newFakeCollector(fakeCollector{name: "first", global: true, meet: meet}),
+				newFakeCollector(fakeCollector{name: "second", global: true, meet: meet}),
+				newFakeCollector(fakeCollector{name: "third", meet: meet}),
+			)),
+		).
+		Parameters(
+			WithRegions([]string{"eu-west-1"}),
+			WithResources([]string{"first", "second", "third"}),
+		).
+		Build().
+		List(ctx)
+
+	assert.Empty(t, report.Errors)
+	assert.Len(t, res, 3)
+}
@@ -0,0 +1,69 @@
+package lister
+
+import (
+	conn "github.com/vcsomor/aws-resources/internal/aws_connector"
+	"sync"
+)
+
+const (
+	operationCreateClient = "CreateClient"
+)
+
+// ResourceError describes a failed operation which left the inventory incomplete
+type ResourceError struct {
+	ResourceType string `json:"resourceType"`
+	Region       string `json:"region,omitempty"`
+	Resource     string `json:"resource,omitempty"`
+	Operation    string `json:"operation"`
+	Class        string `json:"class"`
+	Message      string `json:"message"`
+}
+
+// Report summarizes the problems of a listing run
+type Report struct {
+	Errors []ResourceError `json:"errors"`
+}
+
+// Recorder collects the errors of a listing run, it is safe for concurrent use
+type Recorder struct {
+	mu     sync.Mutex
+	errors []ResourceError
+}
+
+func NewRecorder() *Recorder {
+	return &Recorder{}
+}
+
+func (r *Recorder) Record(e ResourceError) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.errors = append(r.errors, e)
+}
+
+func (r *Recorder) Report() Report {
+	errs := []ResourceError{}
+	if r == nil {
+		return Report{Errors: errs}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return Report{
+		Errors: append(errs, r.errors...),
+	}
+}
+
+func newResourceError(resourceType, region, resource, operation string, err error) ResourceError {
+	return ResourceError{
+		ResourceType: resourceType,
+		Region:       region,
+		Resource:     resource,
+		Operation:    operation,
+		Class:        string(conn.ClassifyError(err)),
+		Message:      err.Error(),
+	}
+}
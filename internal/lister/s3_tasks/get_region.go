@@ -8,52 +8,46 @@ import (
 )
 
 type getRegionTask struct {
-	ctx        context.Context
 	logger     *logrus.Entry
 	client     conn.S3Client
 	bucketName string
 }
 
 type GetRegionResult struct {
-	BucketName string
-	Region     string
-	Error      error
+	BucketName         string
+	LocationConstraint string
+	Region             string
 }
 
-var _ executor.Task = (*getRegionTask)(nil)
+var _ executor.Task[GetRegionResult] = (*getRegionTask)(nil)
 
 func NewS3GetRegionTask(
-	ctx context.Context,
 	logger *logrus.Entry,
 	client conn.S3Client,
 	bucketName string,
-) executor.Task {
+) executor.Task[GetRegionResult] {
 	return &getRegionTask{
-		ctx:        ctx,
 		logger:     logger,
 		client:     client,
 		bucketName: bucketName,
 	}
 }
 
-func (t *getRegionTask) Execute() any {
-	res, err := t.client.GetRegion(t.ctx, conn.NewGetS3RegionParams(t.bucketName))
+func (t *getRegionTask) Execute(ctx context.Context) (GetRegionResult, error) {
+	res, err := t.client.GetRegion(ctx, conn.NewGetS3RegionParams(t.bucketName))
 	if err != nil {
 		t.logger.WithError(err).
 			Error("unable to get region")
-		return GetRegionResult{
-			BucketName: t.bucketName,
-			Error:      err,
-		}
+		return GetRegionResult{BucketName: t.bucketName}, err
 	}
 
 	t.logger.Debugf("bucket region fetched")
 
 	return GetRegionResult{
-		BucketName: t.bucketName,
-		Region:     res.Region,
-		Error:      nil,
-	}
+		BucketName:         t.bucketName,
+		LocationConstraint: res.LocationConstraint,
+		Region:             res.Region,
+	}, nil
 }
 
 func FindRegionResult(bucketName string, results []GetRegionResult) GetRegionResult {
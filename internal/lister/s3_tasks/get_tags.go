@@ -8,7 +8,6 @@ import (
 )
 
 type getTagsTask struct {
-	ctx        context.Context
 	logger     *logrus.Entry
 	client     conn.S3Client
 	bucketName string
@@ -17,34 +16,34 @@ type getTagsTask struct {
 type GetTagsResult struct {
 	BucketName string
 	Tags       map[string]*string
-	Error      error
 }
 
-var _ executor.Task = (*getTagsTask)(nil)
+var _ executor.Task[GetTagsResult] = (*getTagsTask)(nil)
 
 func NewS3GetTagsTask(
-	ctx context.Context,
 	logger *logrus.Entry,
 	client conn.S3Client,
 	bucketName string,
-) executor.Task {
+) executor.Task[GetTagsResult] {
 	return &getTagsTask{
-		ctx:        ctx,
 		logger:     logger,
 		client:     client,
 		bucketName: bucketName,
 	}
 }
 
-func (t *getTagsTask) Execute() any {
-	res, err := t.client.GetTags(t.ctx, conn.NewGetS3BucketTagsParams(t.bucketName))
+func (t *getTagsTask) Execute(ctx context.Context) (GetTagsResult, error) {
+	res, err := t.client.GetTags(ctx, conn.NewGetS3BucketTagsParams(t.bucketName))
 	if err != nil {
-		t.logger.WithError(err).
-			Error("unable to get tags")
-		return GetTagsResult{
-			BucketName: t.bucketName,
-			Error:      err,
+		class := conn.ClassifyError(err)
+		logger := t.logger.WithError(err).
+			WithField("error-class", class)
+		if class == conn.ErrorClassAccessDenied {
+			logger.Warn("unable to get tags")
+		} else {
+			logger.Error("unable to get tags")
 		}
+		return GetTagsResult{BucketName: t.bucketName}, err
 	}
 
 	t.logger.Debugf("bucket tags fetched")
@@ -52,8 +51,7 @@ func (t *getTagsTask) Execute() any {
 	return GetTagsResult{
 		BucketName: t.bucketName,
 		Tags:       res.Tags,
-		Error:      nil,
-	}
+	}, nil
 }
 
 func FindGetTagsResult(bucketName string, results []GetTagsResult) GetTagsResult {
@@ -9,7 +9,6 @@ import (
 )
 
 type listTask struct {
-	ctx    context.Context
 	logger *logrus.Entry
 	client conn.S3Client
 }
@@ -21,29 +20,26 @@ type ListTaskBucketData struct {
 
 type ListTaskResult struct {
 	Buckets []ListTaskBucketData
-	Error   error
 }
 
-var _ executor.Task = (*listTask)(nil)
+var _ executor.Task[ListTaskResult] = (*listTask)(nil)
 
 func NewListTask(
-	ctx context.Context,
 	logger *logrus.Entry,
 	client conn.S3Client,
-) executor.Task {
+) executor.Task[ListTaskResult] {
 	return &listTask{
-		ctx:    ctx,
 		logger: logger,
 		client: client,
 	}
 }
 
-func (t *listTask) Execute() any {
-	buckets, err := t.client.List(t.ctx, conn.ListS3Params{})
+func (t *listTask) Execute(ctx context.Context) (ListTaskResult, error) {
+	buckets, err := t.client.List(ctx, conn.ListS3Params{})
 	if err != nil {
 		t.logger.WithError(err).
 			Error("unable to list buckets")
-		return ListTaskResult{Error: err}
+		return ListTaskResult{}, err
 	}
 
 	var results []ListTaskBucketData
@@ -57,7 +53,7 @@ func (t *listTask) Execute() any {
 
 	t.logger.Debugf("resouces listed")
 
-	return ListTaskResult{Buckets: results}
+	return ListTaskResult{Buckets: results}, nil
 }
 
 func FindListBucketData(bucketName string, dt []ListTaskBucketData) ListTaskBucketData {
@@ -0,0 +1,62 @@
+package lister
+
+import "sync"
+
+// Sink receives every resource as soon as it is assembled, it may be called concurrently
+type Sink func(r Result)
+
+// Tee hands every resource over to all the sinks
+func Tee(sinks ...Sink) Sink {
+	return func(r Result) {
+		for _, s := range sinks {
+			s(r)
+		}
+	}
+}
+
+// Counter counts the streamed resources per resource type, it is safe for concurrent use
+type Counter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func NewCounter() *Counter {
+	return &Counter{
+		counts: map[string]int{},
+	}
+}
+
+func (c *Counter) Put(r Result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.counts[r.ResourceType()]++
+}
+
+func (c *Counter) Counts() map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	counts := make(map[string]int, len(c.counts))
+	for t, n := range c.counts {
+		counts[t] = n
+	}
+	return counts
+}
+
+// collectingSink keeps every resource in memory, it is safe for concurrent use
+type collectingSink struct {
+	mu  sync.Mutex
+	res []Result
+}
+
+func (s *collectingSink) Put(r Result) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.res = append(s.res, r)
+}
+
+func (s *collectingSink) Results() []Result {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.res
+}
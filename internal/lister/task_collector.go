@@ -0,0 +1,87 @@
+package lister
+
+import (
+	"context"
+	"github.com/vcsomor/aws-resources/internal/executor"
+)
+
+type taskCollector[T any] struct {
+	collector TaskCollector[T]
+}
+
+type regionalTask[T any] struct {
+	region string
+	task   executor.Task[T]
+}
+
+var _ Collector = (*taskCollector[any])(nil)
+
+// NewTaskCollector makes a Collector which runs the tasks of the TaskCollector in every region of the
+// environment, or once in the default region for global resource types
+func NewTaskCollector[T any](c TaskCollector[T]) Collector {
+	return taskCollector[T]{
+		collector: c,
+	}
+}
+
+func (c taskCollector[T]) Name() string {
+	return c.collector.Name()
+}
+
+func (c taskCollector[T]) Collect(ctx context.Context, env Environment) {
+	regionalTasks := c.makeTasks(ctx, env)
+
+	var tasks []executor.Task[T]
+	for _, t := range regionalTasks {
+		tasks = append(tasks, t.task)
+	}
+
+	for r := range executor.ExecuteAllAsCompleted(ctx, env.Executor, tasks) {
+		env.Emit(c.collector.Assemble(ctx, env, TaskResult[T]{
+			SynchronousResult: r.SynchronousResult,
+			Region:            regionalTasks[r.Index].region,
+		})...)
+	}
+}
+
+func (c taskCollector[T]) makeTasks(ctx context.Context, env Environment) []regionalTask[T] {
+	if c.collector.Global() || env.Regions == nil {
+		return c.tasksInRegion(ctx, env, nil)
+	}
+
+	var tasks []regionalTask[T]
+	for _, region := range env.Regions {
+		currRegion := region
+		tasks = append(tasks, c.tasksInRegion(ctx, env, &currRegion)...)
+	}
+	return tasks
+}
+
+func (c taskCollector[T]) tasksInRegion(ctx context.Context, env Environment, region *string) []regionalTask[T] {
+	regionName := ""
+	if region != nil {
+		regionName = *region
+	}
+	env.Logger = env.Logger.WithField(logKeyRegion, regionLogValue(regionName))
+
+	client, err := c.collector.NewClient(ctx, env.ClientFactory, region)
+	if err != nil {
+		env.Logger.WithError(err).
+			Error("unable to create the client")
+		env.RecordError(regionName, "", operationCreateClient, err)
+		return nil
+	}
+
+	var tasks []regionalTask[T]
+	for _, t := range c.collector.NewTasks(ctx, env, client) {
+		tasks = append(tasks, regionalTask[T]{region: regionName, task: t})
+	}
+	return tasks
+}
+
+func regionLogValue(region string) string {
+	if region == "" {
+		return "default"
+	}
+	return region
+}
@@ -1,6 +1,7 @@
 package lister
 
 import (
+	"strings"
 	"time"
 )
 
@@ -8,9 +9,11 @@ const (
 	logKeyRegion        = "region"
 	logKeyResourceType  = "resource-type"
 	logKeyResourceCount = "resource-count"
+	logKeyErrorCount    = "error-count"
 
-	s3ResourceType  = "S3"
-	rdsResourceType = "RDS"
+	s3ResourceType  = "s3"
+	rdsResourceType = "rds"
+	ec2ResourceType = "ec2"
 )
 
 type Result struct {
@@ -18,14 +21,97 @@ type Result struct {
 	ID           string     `json:"id"`
 	CreationTime *time.Time `json:"creationTime"`
 
-	Properties any `json:"properties"`
+	Properties any      `json:"properties"`
+	Warnings   []string `json:"warnings,omitempty"`
+
+	// Type is the name of the collector which listed the resource, set when the resource is emitted
+	Type string `json:"-"`
+}
+
+// ResourceType returns the resource type as used in the --resources argument e.g.: "s3" or "rds"
+func (r Result) ResourceType() string {
+	return r.Type
+}
+
+// Region returns the region part of the ARN, for the region-less ARNs of S3 buckets it is the region
+// found in the properties
+func (r Result) Region() string {
+	parts := strings.SplitN(r.Arn, ":", 5)
+	if len(parts) >= 4 && parts[3] != "" {
+		return parts[3]
+	}
+	if p, ok := r.Properties.(regionalProperties); ok {
+		return p.ResourceRegion()
+	}
+	return ""
+}
+
+// Tags returns the tags of the resource, nil if the properties carry no tags
+func (r Result) Tags() map[string]*string {
+	if p, ok := r.Properties.(TaggedProperties); ok {
+		return p.ResourceTags()
+	}
+	return nil
+}
+
+// TaggedProperties are the properties of a resource type which supports tags
+type TaggedProperties interface {
+	ResourceTags() map[string]*string
+}
+
+type regionalProperties interface {
+	ResourceRegion() string
+}
+
+// TypedResult is a Result carrying its resource type, used by the line oriented outputs
+// where a resource has to be self-describing
+type TypedResult struct {
+	ResourceType string `json:"resourceType"`
+	Result
+}
+
+func (r Result) Typed() TypedResult {
+	return TypedResult{
+		ResourceType: r.ResourceType(),
+		Result:       r,
+	}
+}
+
+// EnvelopeResourcesKey is the field of the envelope holding the resources, they are streamed
+// in front of the rest of the Envelope while the listing runs
+const EnvelopeResourcesKey = "resources"
+
+// Envelope is what completes the outcome of a listing run once every resource has been streamed
+type Envelope struct {
+	Errors   []ResourceError `json:"errors"`
+	Manifest Manifest        `json:"manifest"`
+}
+
+func NewEnvelope(manifest Manifest, report Report) Envelope {
+	errs := report.Errors
+	if errs == nil {
+		errs = []ResourceError{}
+	}
+	return Envelope{
+		Errors:   errs,
+		Manifest: manifest,
+	}
 }
 
 type S3Data struct {
 	LocationConstraint string             `json:"locationConstraint"`
+	Region             string             `json:"region"`
 	Tags               map[string]*string `json:"tags"`
 }
 
+func (d S3Data) ResourceRegion() string {
+	return d.Region
+}
+
+func (d S3Data) ResourceTags() map[string]*string {
+	return d.Tags
+}
+
 type RDSData struct {
 	InstanceType     *string            `json:"instanceType"`
 	AvailabilityZone *string            `json:"availabilityZone"`
@@ -38,3 +124,23 @@ type RDSData struct {
 	MultiTenant      *bool              `json:"multiTenant"`
 	Tags             map[string]*string `json:"tags"`
 }
+
+func (d RDSData) ResourceTags() map[string]*string {
+	return d.Tags
+}
+
+type EC2Data struct {
+	InstanceType       string             `json:"instanceType"`
+	State              string             `json:"state"`
+	ImageID            *string            `json:"imageId"`
+	VpcID              *string            `json:"vpcId"`
+	SubnetID           *string            `json:"subnetId"`
+	PrivateIPAddress   *string            `json:"privateIpAddress"`
+	PublicIPAddress    *string            `json:"publicIpAddress"`
+	IamInstanceProfile *string            `json:"iamInstanceProfile"`
+	Tags               map[string]*string `json:"tags"`
+}
+
+func (d EC2Data) ResourceTags() map[string]*string {
+	return d.Tags
+}
@@ -0,0 +1,69 @@
+package lister
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestResourceType(t *testing.T) {
+	assert.Equal(t, "s3", Result{Arn: "arn:aws:s3:::my-bucket", Type: "s3"}.ResourceType())
+	assert.Equal(t, "database", Result{Arn: "arn:aws:rds:eu-west-1:123456789012:db:my-db", Type: "database"}.ResourceType())
+	assert.Equal(t, "", Result{Arn: "arn:aws:s3:::my-bucket"}.ResourceType())
+}
+
+func TestEmitSetsResourceType(t *testing.T) {
+	var emitted []Result
+	env := Environment{
+		ResourceType: "database",
+		Sink: func(r Result) {
+			emitted = append(emitted, r)
+		},
+	}
+
+	env.Emit(Result{Arn: "arn:aws:rds:eu-west-1:123456789012:db:my-db"}, Result{Arn: "arn:aws:rds:eu-west-1:123456789012:db:other", Type: "rds"})
+	assert.Len(t, emitted, 2)
+	assert.Equal(t, "database", emitted[0].ResourceType())
+	assert.Equal(t, "database", emitted[1].ResourceType())
+}
+
+func TestRegion(t *testing.T) {
+	assert.Equal(t, "eu-west-1", Result{Arn: "arn:aws:rds:eu-west-1:123456789012:db:my-db"}.Region())
+	assert.Equal(t, "us-east-2", Result{
+		Arn:        "arn:aws:s3:::my-bucket",
+		Properties: S3Data{Region: "us-east-2"},
+	}.Region())
+	assert.Equal(t, "", Result{Arn: "arn:aws:s3:::my-bucket"}.Region())
+	assert.Equal(t, "", Result{}.Region())
+}
+
+func TestTags(t *testing.T) {
+	owner := "team-a"
+	tags := map[string]*string{"Owner": &owner}
+	assert.Equal(t, tags, Result{Properties: RDSData{Tags: tags}}.Tags())
+	assert.Equal(t, tags, Result{Properties: EC2Data{Tags: tags}}.Tags())
+	assert.Equal(t, tags, Result{Properties: S3Data{Tags: tags}}.Tags())
+	assert.Nil(t, Result{Properties: map[string]any{"tags": tags}}.Tags())
+}
+
+func TestTypedResult(t *testing.T) {
+	b, err := json.Marshal(Result{
+		Arn:  "arn:aws:s3:::my-bucket",
+		ID:   "my-bucket",
+		Type: "s3",
+	}.Typed())
+	assert.Nil(t, err)
+	assert.JSONEq(t,
+		`{"resourceType":"s3","arn":"arn:aws:s3:::my-bucket","id":"my-bucket","creationTime":null,"properties":null}`,
+		string(b))
+}
+
+func TestEmptyEnvelope(t *testing.T) {
+	b, err := json.Marshal(NewEnvelope(Manifest{}, Report{}))
+	assert.Nil(t, err)
+
+	var decoded map[string]any
+	assert.Nil(t, json.Unmarshal(b, &decoded))
+	assert.Contains(t, decoded, "manifest")
+	assert.Equal(t, []any{}, decoded["errors"])
+}
@@ -0,0 +1,99 @@
+package csv
+
+import (
+	"bytes"
+	encodingcsv "encoding/csv"
+	"fmt"
+	"github.com/vcsomor/aws-resources/internal/lister"
+	"github.com/vcsomor/aws-resources/internal/lister/writer"
+	"os"
+	"path/filepath"
+)
+
+const (
+	DefaultOutputFile = "resources.csv"
+)
+
+type Options struct {
+	outputFile string
+}
+
+type OptionFnc func(*Options) error
+
+func WithOutputFile(outputFile string) OptionFnc {
+	return func(options *Options) error {
+		if !filepath.IsLocal(outputFile) {
+			return fmt.Errorf("the file is not a relative filename %s", outputFile)
+		}
+		options.outputFile = outputFile
+		return nil
+	}
+}
+
+type csvFileWriter struct {
+	to   string
+	opts Options
+}
+
+var _ writer.Writer = (*csvFileWriter)(nil)
+
+// NewWriter creates a writer which flattens the written object (a single object or a list of them)
+// into dotted columns and writes it as one CSV file with the union of the columns as header.
+func NewWriter(to string, opts ...OptionFnc) (writer.Writer, error) {
+	options := Options{
+		outputFile: DefaultOutputFile,
+	}
+	for _, fn := range opts {
+		if err := fn(&options); err != nil {
+			return nil, fmt.Errorf("unable to create the CSV File Writer: %w", err)
+		}
+	}
+
+	return &csvFileWriter{
+		to:   to,
+		opts: options,
+	}, nil
+}
+
+func (w csvFileWriter) Write(obj any) error {
+	// the JSON field names are the column names
+	rows, err := lister.FlattenJSONRows(obj)
+	if err != nil {
+		return fmt.Errorf("unsupported CSV data: %w", err)
+	}
+
+	b, err := serialize(rows)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(w.to, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(w.to, w.opts.outputFile), b, 0o644)
+}
+
+func serialize(rows []map[string]any) ([]byte, error) {
+	columns := lister.Columns(rows)
+
+	buf := bytes.Buffer{}
+	enc := encodingcsv.NewWriter(&buf)
+	if err := enc.Write(columns); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		record := make([]string, len(columns))
+		for i, column := range columns {
+			record[i] = lister.FormatValue(row[column])
+		}
+		if err := enc.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	enc.Flush()
+	return buf.Bytes(), enc.Error()
+}
@@ -0,0 +1,101 @@
+package csv
+
+import (
+	"github.com/aws/smithy-go/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/vcsomor/aws-resources/internal/lister"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDefaultResourceFile(t *testing.T) {
+	root := t.TempDir()
+
+	w, err := NewWriter(root)
+	assert.Nil(t, err)
+
+	err = w.Write(struct {
+		A string `json:"a"`
+		B int    `json:"b"`
+	}{"hello", 42})
+	assert.Nil(t, err)
+
+	assert.Equal(t,
+		"a,b\nhello,42\n",
+		readFile(t, filepath.Join(root, "resources.csv")))
+}
+
+func TestResultsColumnUnion(t *testing.T) {
+	root := t.TempDir()
+	created := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	w, err := NewWriter(root, WithOutputFile("s3.csv"))
+	assert.Nil(t, err)
+
+	err = w.Write([]lister.Result{
+		{
+			Arn:          "arn:aws:s3:::bucket-1",
+			ID:           "bucket-1",
+			CreationTime: &created,
+			Properties: lister.S3Data{
+				LocationConstraint: "EU",
+				Region:             "eu-west-1",
+				Tags: map[string]*string{
+					"Owner": ptr.String("finance"),
+				},
+			},
+		},
+		{
+			Arn: "arn:aws:s3:::bucket-2",
+			ID:  "bucket-2",
+			Properties: lister.S3Data{
+				LocationConstraint: "us-east-2",
+				Region:             "us-east-2",
+				Tags: map[string]*string{
+					"Team, Cost": ptr.String("platform"),
+				},
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	assert.Equal(t,
+		`arn,creationTime,id,properties.locationConstraint,properties.region,properties.tags.Owner,"properties.tags.Team, Cost"
+arn:aws:s3:::bucket-1,2024-03-01T10:00:00Z,bucket-1,EU,eu-west-1,finance,
+arn:aws:s3:::bucket-2,,bucket-2,us-east-2,us-east-2,,platform
+`,
+		readFile(t, filepath.Join(root, "s3.csv")))
+}
+
+func TestUnsupportedData(t *testing.T) {
+	root := t.TempDir()
+
+	w, err := NewWriter(root)
+	assert.Nil(t, err)
+
+	assert.ErrorContains(t,
+		w.Write([]string{"a", "b"}),
+		"unsupported CSV data: unsupported type string, only objects can be flattened")
+}
+
+func TestConstructorErrors(t *testing.T) {
+	root := t.TempDir()
+
+	_, err := NewWriter(root, WithOutputFile("/my.csv"))
+	assert.ErrorContains(t,
+		err,
+		"unable to create the CSV File Writer: the file is not a relative filename /my.csv")
+
+	_, err = NewWriter(root, WithOutputFile("../my.csv"))
+	assert.ErrorContains(t,
+		err,
+		"unable to create the CSV File Writer: the file is not a relative filename ../my.csv")
+}
+
+func readFile(t *testing.T, f string) string {
+	b, err := os.ReadFile(f)
+	assert.Nil(t, err, "unable to read file %s", f)
+	return string(b)
+}
@@ -0,0 +1,261 @@
+package htmlreport
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"github.com/vcsomor/aws-resources/internal/lister"
+	"github.com/vcsomor/aws-resources/internal/lister/writer"
+	"html/template"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultOutputFile = "report.html"
+	noRegion          = "global"
+)
+
+//go:embed report.html.tmpl
+var reportTemplate string
+
+var page = template.Must(template.New("report").
+	Funcs(template.FuncMap{
+		"percent": percent,
+	}).
+	Parse(reportTemplate))
+
+// Report is the data of the HTML report
+type Report struct {
+	Manifest  lister.Manifest
+	Resources []lister.Result
+	Errors    []lister.ResourceError
+}
+
+type Options struct {
+	outputFile string
+}
+
+type OptionFnc func(*Options) error
+
+func WithOutputFile(outputFile string) OptionFnc {
+	return func(options *Options) error {
+		if !filepath.IsLocal(outputFile) {
+			return fmt.Errorf("the file is not a relative filename %s", outputFile)
+		}
+		options.outputFile = outputFile
+		return nil
+	}
+}
+
+type htmlReportWriter struct {
+	to   string
+	opts Options
+}
+
+var _ writer.Writer = (*htmlReportWriter)(nil)
+
+// NewWriter creates a writer which renders a Report into a single HTML file, the styles and the
+// scripts are embedded so the file can be opened or sent on its own
+func NewWriter(to string, opts ...OptionFnc) (writer.Writer, error) {
+	options := Options{
+		outputFile: DefaultOutputFile,
+	}
+	for _, fn := range opts {
+		if err := fn(&options); err != nil {
+			return nil, fmt.Errorf("unable to create the HTML Report Writer: %w", err)
+		}
+	}
+
+	return &htmlReportWriter{
+		to:   to,
+		opts: options,
+	}, nil
+}
+
+func (w htmlReportWriter) Write(obj any) error {
+	r, ok := obj.(Report)
+	if !ok {
+		return fmt.Errorf("unsupported type %T, the HTML Report Writer renders Reports", obj)
+	}
+
+	v, err := newView(r)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err = page.Execute(&buf, v); err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(w.to, os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(w.to, w.opts.outputFile), buf.Bytes(), 0o644)
+}
+
+type view struct {
+	Manifest    lister.Manifest
+	GeneratedAt string
+	Total       int
+
+	Regions []string
+	Summary []summaryRow
+	Types   []typeTable
+
+	TagCoverage []tagCoverage
+	TagKeys     []tagKey
+
+	Errors []lister.ResourceError
+}
+
+type summaryRow struct {
+	ResourceType string
+	Counts       []int
+	Total        int
+}
+
+type typeTable struct {
+	ResourceType string
+	Columns      []string
+	Rows         [][]string
+}
+
+type tagCoverage struct {
+	ResourceType string
+	Total        int
+	Tagged       int
+}
+
+type tagKey struct {
+	Key       string
+	Resources int
+	Taggable  int
+}
+
+func newView(r Report) (view, error) {
+	byType := map[string][]lister.Result{}
+	var types []string
+	regionSet := map[string]struct{}{}
+	for _, res := range r.Resources {
+		t := res.ResourceType()
+		if _, exist := byType[t]; !exist {
+			types = append(types, t)
+		}
+		byType[t] = append(byType[t], res)
+		regionSet[regionOf(res)] = struct{}{}
+	}
+	slices.Sort(types)
+
+	var regions []string
+	for region := range regionSet {
+		regions = append(regions, region)
+	}
+	slices.Sort(regions)
+
+	v := view{
+		Manifest:    r.Manifest,
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		Total:       len(r.Resources),
+		Regions:     regions,
+		Errors:      r.Errors,
+	}
+
+	taggable := 0
+	keyCounts := map[string]int{}
+	for _, t := range types {
+		resources := byType[t]
+
+		row := summaryRow{
+			ResourceType: t,
+			Counts:       make([]int, len(regions)),
+			Total:        len(resources),
+		}
+		coverage := tagCoverage{
+			ResourceType: t,
+			Total:        len(resources),
+		}
+		for _, res := range resources {
+			row.Counts[slices.Index(regions, regionOf(res))]++
+
+			if _, ok := res.Properties.(lister.TaggedProperties); !ok {
+				continue
+			}
+			taggable++
+			tags := res.Tags()
+			if len(tags) > 0 {
+				coverage.Tagged++
+			}
+			for k := range tags {
+				keyCounts[k]++
+			}
+		}
+		v.Summary = append(v.Summary, row)
+		v.TagCoverage = append(v.TagCoverage, coverage)
+
+		table, err := newTypeTable(t, resources)
+		if err != nil {
+			return view{}, err
+		}
+		v.Types = append(v.Types, table)
+	}
+
+	for k, n := range keyCounts {
+		v.TagKeys = append(v.TagKeys, tagKey{
+			Key:       k,
+			Resources: n,
+			Taggable:  taggable,
+		})
+	}
+	slices.SortFunc(v.TagKeys, func(a, b tagKey) int {
+		if a.Resources != b.Resources {
+			return b.Resources - a.Resources
+		}
+		if a.Key < b.Key {
+			return -1
+		}
+		return 1
+	})
+
+	return v, nil
+}
+
+// newTypeTable flattens the resources of a type, the columns are the union of the flattened keys
+func newTypeTable(resourceType string, resources []lister.Result) (typeTable, error) {
+	flattened, err := lister.FlattenJSONRows(resources)
+	if err != nil {
+		return typeTable{}, err
+	}
+	columns := lister.Columns(flattened)
+
+	table := typeTable{
+		ResourceType: resourceType,
+		Columns:      columns,
+	}
+	for _, flat := range flattened {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = lister.FormatValue(flat[column])
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table, nil
+}
+
+func regionOf(r lister.Result) string {
+	if region := r.Region(); region != "" {
+		return region
+	}
+	return noRegion
+}
+
+func percent(part, whole int) string {
+	if whole == 0 {
+		return "-"
+	}
+	return strconv.FormatFloat(float64(part)*100/float64(whole), 'f', 1, 64) + "%"
+}
@@ -0,0 +1,70 @@
+package htmlreport
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/vcsomor/aws-resources/internal/lister"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteReport(t *testing.T) {
+	root := t.TempDir()
+	owner, engine := "team-a", "postgres"
+
+	w, err := NewWriter(root)
+	assert.Nil(t, err)
+
+	err = w.Write(Report{
+		Manifest: lister.Manifest{
+			Account:   "123456789012",
+			StartedAt: time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC),
+		},
+		Resources: []lister.Result{
+			{
+				Arn:        "arn:aws:rds:eu-west-1:123456789012:db:my-db",
+				Type:       "rds",
+				ID:         "my-db",
+				Properties: lister.RDSData{Engine: &engine, Tags: map[string]*string{"Owner": &owner}},
+			},
+			{
+				Arn:        "arn:aws:s3:::<bucket>",
+				Type:       "s3",
+				ID:         "<bucket>",
+				Properties: lister.S3Data{Region: "us-east-2"},
+			},
+		},
+		Errors: []lister.ResourceError{{ResourceType: "ec2", Region: "eu-west-1", Class: "access-denied"}},
+	})
+	assert.Nil(t, err)
+
+	b, err := os.ReadFile(filepath.Join(root, DefaultOutputFile))
+	assert.Nil(t, err)
+	report := string(b)
+
+	assert.Contains(t, report, "<th>eu-west-1</th><th>us-east-2</th>")
+	assert.Contains(t, report, `<td>rds</td><td class="number">1</td><td class="number">0</td><td class="number">1</td>`)
+	assert.Contains(t, report, `<table id="table-rds" class="sortable">`)
+	assert.Contains(t, report, "<th>properties.engine</th>")
+	assert.Contains(t, report, "<td>postgres</td>")
+	assert.Contains(t, report, `<td>Owner</td><td class="number">1</td><td class="number">50.0%</td>`)
+	assert.Contains(t, report, "&lt;bucket&gt;")
+	assert.NotContains(t, report, "<bucket>")
+	assert.Contains(t, report, "1 operation(s) failed")
+	assert.False(t, strings.Contains(report, "http://") || strings.Contains(report, "https://"),
+		"the report refers to external assets")
+}
+
+func TestUnsupportedData(t *testing.T) {
+	w, err := NewWriter(t.TempDir())
+	assert.Nil(t, err)
+	assert.NotNil(t, w.Write([]lister.Result{}))
+}
+
+func TestPercent(t *testing.T) {
+	assert.Equal(t, "-", percent(0, 0))
+	assert.Equal(t, "33.3%", percent(1, 3))
+	assert.Equal(t, "100.0%", percent(2, 2))
+}
@@ -0,0 +1,62 @@
+package ndjson
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/vcsomor/aws-resources/internal/lister/writer"
+	"io"
+	"os"
+	"sync"
+)
+
+type Options struct {
+	out io.Writer
+}
+
+type OptionFnc func(*Options) error
+
+// WithOutput sets where the lines are written to, the default is the standard output
+func WithOutput(out io.Writer) OptionFnc {
+	return func(options *Options) error {
+		if out == nil {
+			return fmt.Errorf("the output is missing")
+		}
+		options.out = out
+		return nil
+	}
+}
+
+// ndjsonWriter writes every object as a compact JSON document on its own line, it is safe for concurrent use
+type ndjsonWriter struct {
+	Options
+	mu sync.Mutex
+}
+
+var _ writer.Writer = (*ndjsonWriter)(nil)
+
+func NewWriter(opts ...OptionFnc) (writer.Writer, error) {
+	options := Options{
+		out: os.Stdout,
+	}
+	for _, fn := range opts {
+		if err := fn(&options); err != nil {
+			return nil, fmt.Errorf("unable to create the NDJSON Writer: %w", err)
+		}
+	}
+
+	return &ndjsonWriter{
+		Options: options,
+	}, nil
+}
+
+func (w *ndjsonWriter) Write(obj any) error {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err = w.out.Write(append(b, '\n'))
+	return err
+}
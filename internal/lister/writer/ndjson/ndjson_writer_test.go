@@ -0,0 +1,29 @@
+package ndjson
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWriteLines(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewWriter(WithOutput(&out))
+	assert.Nil(t, err)
+
+	assert.Nil(t, w.Write(map[string]any{
+		"arn":  "arn:aws:s3:::bucket-1",
+		"tags": map[string]string{"Owner": "team a"},
+	}))
+	assert.Nil(t, w.Write(map[string]any{"arn": "arn:aws:s3:::bucket-2"}))
+
+	assert.Equal(t,
+		`{"arn":"arn:aws:s3:::bucket-1","tags":{"Owner":"team a"}}`+"\n"+
+			`{"arn":"arn:aws:s3:::bucket-2"}`+"\n",
+		out.String())
+}
+
+func TestMissingOutput(t *testing.T) {
+	_, err := NewWriter(WithOutput(nil))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,207 @@
+package sqlite
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/vcsomor/aws-resources/internal/lister/writer"
+	_ "modernc.org/sqlite"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const DefaultOutputFile = "inventory.db"
+
+const schema = `
+CREATE TABLE IF NOT EXISTS scans (
+	id        INTEGER PRIMARY KEY AUTOINCREMENT,
+	timestamp TEXT,
+	account   TEXT,
+	version   TEXT
+);
+CREATE TABLE IF NOT EXISTS resources (
+	scan_id       INTEGER NOT NULL REFERENCES scans(id),
+	arn           TEXT NOT NULL,
+	type          TEXT NOT NULL,
+	region        TEXT,
+	creation_time TEXT,
+	properties    TEXT,
+	PRIMARY KEY (arn, scan_id)
+);
+CREATE INDEX IF NOT EXISTS resources_scan_type ON resources (scan_id, type);
+CREATE TABLE IF NOT EXISTS tags (
+	scan_id INTEGER NOT NULL REFERENCES scans(id),
+	arn     TEXT NOT NULL,
+	key     TEXT NOT NULL,
+	value   TEXT,
+	PRIMARY KEY (arn, scan_id, key)
+);
+CREATE INDEX IF NOT EXISTS tags_key_value ON tags (key, value);
+`
+
+// Resource is a row of the resources table, the tags go to the tags table
+type Resource struct {
+	Arn          string
+	Type         string
+	Region       string
+	CreationTime *time.Time
+	Properties   any
+	Tags         map[string]*string
+}
+
+// Scan is a row of the scans table
+type Scan struct {
+	Timestamp time.Time
+	Account   string
+	Version   string
+}
+
+type Options struct {
+	outputFile string
+}
+
+type OptionFnc func(*Options) error
+
+func WithOutputFile(outputFile string) OptionFnc {
+	return func(options *Options) error {
+		if !filepath.IsLocal(outputFile) {
+			return fmt.Errorf("the file is not a relative filename %s", outputFile)
+		}
+		options.outputFile = outputFile
+		return nil
+	}
+}
+
+// Writer stores a scan in a SQLite database, the scans are added to the ones already in the file.
+// The rows are written in a single transaction which is committed by Close, it is safe for concurrent use.
+type Writer struct {
+	mu     sync.Mutex
+	db     *sql.DB
+	tx     *sql.Tx
+	scanID int64
+}
+
+var _ writer.Writer = (*Writer)(nil)
+
+// NewWriter opens or creates the database file in the directory and starts a new scan
+func NewWriter(to string, opts ...OptionFnc) (*Writer, error) {
+	options := Options{
+		outputFile: DefaultOutputFile,
+	}
+	for _, fn := range opts {
+		if err := fn(&options); err != nil {
+			return nil, fmt.Errorf("unable to create the SQLite Writer: %w", err)
+		}
+	}
+
+	if err := os.MkdirAll(to, os.ModePerm); err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite", filepath.Join(to, options.outputFile))
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := begin(db)
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("unable to start the scan: %w", err)
+	}
+	return w, nil
+}
+
+func begin(db *sql.DB) (*Writer, error) {
+	if _, err := db.Exec(schema); err != nil {
+		return nil, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	res, err := tx.Exec(`INSERT INTO scans (timestamp) VALUES (NULL)`)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	id, err := res.LastInsertId()
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	return &Writer{
+		db:     db,
+		tx:     tx,
+		scanID: id,
+	}, nil
+}
+
+// Write stores a Resource with its tags in the current scan
+func (w *Writer) Write(data any) error {
+	r, ok := data.(Resource)
+	if !ok {
+		return fmt.Errorf("unsupported type %T, the SQLite Writer stores Resources", data)
+	}
+
+	properties, err := json.Marshal(r.Properties)
+	if err != nil {
+		return err
+	}
+
+	var creationTime *string
+	if r.CreationTime != nil {
+		t := r.CreationTime.UTC().Format(time.RFC3339)
+		creationTime = &t
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.tx == nil {
+		return fmt.Errorf("the scan is already closed")
+	}
+	_, err = w.tx.Exec(
+		`INSERT OR REPLACE INTO resources (scan_id, arn, type, region, creation_time, properties) VALUES (?, ?, ?, ?, ?, ?)`,
+		w.scanID, r.Arn, r.Type, r.Region, creationTime, string(properties))
+	if err != nil {
+		return err
+	}
+	for k, v := range r.Tags {
+		_, err = w.tx.Exec(
+			`INSERT OR REPLACE INTO tags (scan_id, arn, key, value) VALUES (?, ?, ?, ?)`,
+			w.scanID, r.Arn, k, v)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close completes the row of the scan, commits every row of it and closes the database
+func (w *Writer) Close(scan Scan) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.tx == nil {
+		return fmt.Errorf("the scan is already closed")
+	}
+	tx := w.tx
+	w.tx = nil
+
+	_, err := tx.Exec(
+		`UPDATE scans SET timestamp = ?, account = ?, version = ? WHERE id = ?`,
+		scan.Timestamp.UTC().Format(time.RFC3339), scan.Account, scan.Version, w.scanID)
+	if err != nil {
+		return errors.Join(err, tx.Rollback(), w.db.Close())
+	}
+	return errors.Join(tx.Commit(), w.db.Close())
+}
+
+// ScanID is the id of the scan in the scans table
+func (w *Writer) ScanID() int64 {
+	return w.scanID
+}
@@ -0,0 +1,83 @@
+package sqlite
+
+import (
+	"database/sql"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWriteScans(t *testing.T) {
+	dir := t.TempDir()
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	owner := "team-a"
+
+	w, err := NewWriter(dir)
+	assert.Nil(t, err)
+	assert.Nil(t, w.Write(Resource{
+		Arn:          "arn:aws:rds:eu-west-1:123456789012:db:my-db",
+		Type:         "rds",
+		Region:       "eu-west-1",
+		CreationTime: &created,
+		Properties:   map[string]any{"engine": "postgres"},
+		Tags:         map[string]*string{"Owner": &owner},
+	}))
+	assert.Nil(t, w.Write(Resource{
+		Arn:    "arn:aws:s3:::my-bucket",
+		Type:   "s3",
+		Region: "us-east-2",
+	}))
+	assert.NotNil(t, w.Write("not a resource"))
+	assert.Nil(t, w.Close(Scan{Timestamp: created, Account: "123456789012", Version: "0.1.0"}))
+	assert.NotNil(t, w.Close(Scan{}))
+
+	second, err := NewWriter(dir)
+	assert.Nil(t, err)
+	assert.Nil(t, second.Write(Resource{Arn: "arn:aws:s3:::my-bucket", Type: "s3"}))
+	assert.Nil(t, second.Close(Scan{Timestamp: created.Add(time.Hour)}))
+	assert.Equal(t, w.ScanID()+1, second.ScanID())
+
+	db, err := sql.Open("sqlite", filepath.Join(dir, DefaultOutputFile))
+	assert.Nil(t, err)
+	defer db.Close()
+
+	var scans int
+	assert.Nil(t, db.QueryRow(`SELECT count(*) FROM scans`).Scan(&scans))
+	assert.Equal(t, 2, scans)
+
+	var timestamp, account, version string
+	assert.Nil(t, db.QueryRow(`SELECT timestamp, account, version FROM scans WHERE id = ?`, w.ScanID()).
+		Scan(&timestamp, &account, &version))
+	assert.Equal(t, "2024-05-01T10:00:00Z", timestamp)
+	assert.Equal(t, "123456789012", account)
+	assert.Equal(t, "0.1.0", version)
+
+	var typ, region, creationTime, properties string
+	assert.Nil(t, db.QueryRow(
+		`SELECT type, region, creation_time, properties FROM resources WHERE arn = ? AND scan_id = ?`,
+		"arn:aws:rds:eu-west-1:123456789012:db:my-db", w.ScanID()).
+		Scan(&typ, &region, &creationTime, &properties))
+	assert.Equal(t, "rds", typ)
+	assert.Equal(t, "eu-west-1", region)
+	assert.Equal(t, "2024-05-01T10:00:00Z", creationTime)
+	assert.JSONEq(t, `{"engine":"postgres"}`, properties)
+
+	var engine string
+	assert.Nil(t, db.QueryRow(`SELECT json_extract(properties, '$.engine') FROM resources WHERE type = 'rds'`).
+		Scan(&engine))
+	assert.Equal(t, "postgres", engine)
+
+	var value string
+	assert.Nil(t, db.QueryRow(`SELECT value FROM tags WHERE key = 'Owner'`).Scan(&value))
+	assert.Equal(t, "team-a", value)
+
+	var buckets int
+	assert.Nil(t, db.QueryRow(`SELECT count(*) FROM resources WHERE arn = 'arn:aws:s3:::my-bucket'`).Scan(&buckets))
+	assert.Equal(t, 2, buckets)
+}
+
+func TestInvalidOutputFile(t *testing.T) {
+	_, err := NewWriter(t.TempDir(), WithOutputFile("../inventory.db"))
+	assert.NotNil(t, err)
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/vcsomor/aws-resources/internal/lister/writer"
+	"io"
+	"os"
 )
 
 const (
@@ -12,6 +14,7 @@ const (
 
 type Options struct {
 	indent string
+	out    io.Writer
 }
 
 type OptionFnc func(*Options) error
@@ -23,6 +26,14 @@ func WithIndentation(indentation string) OptionFnc {
 	}
 }
 
+// WithOutput replaces the standard output e.g.: in tests
+func WithOutput(out io.Writer) OptionFnc {
+	return func(options *Options) error {
+		options.out = out
+		return nil
+	}
+}
+
 type jsonStdoutWriter struct {
 	Options
 }
@@ -32,6 +43,7 @@ var _ writer.Writer = (*jsonStdoutWriter)(nil)
 func NewWriter(opts ...OptionFnc) (writer.Writer, error) {
 	options := Options{
 		indent: DefaultIndentation,
+		out:    os.Stdout,
 	}
 	for _, fn := range opts {
 		if err := fn(&options); err != nil {
@@ -59,5 +71,5 @@ func (w jsonStdoutWriter) serialize(obj any) ([]byte, error) {
 }
 
 func (w jsonStdoutWriter) writeResource(b []byte) {
-	fmt.Printf("%s\n", string(b))
+	_, _ = fmt.Fprintf(w.out, "%s\n", string(b))
 }
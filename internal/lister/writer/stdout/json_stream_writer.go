@@ -0,0 +1,94 @@
+package stdout
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/vcsomor/aws-resources/internal/lister/writer"
+	"os"
+	"strings"
+	"sync"
+)
+
+// StreamWriter prints a JSON object whose array field is written element by element as the elements
+// arrive, Close completes the object with the rest of its fields. It is safe for concurrent use.
+type StreamWriter struct {
+	Options
+
+	mu    sync.Mutex
+	field string
+	items int
+}
+
+var _ writer.Writer = (*StreamWriter)(nil)
+
+func NewStreamWriter(field string, opts ...OptionFnc) (*StreamWriter, error) {
+	options := Options{
+		indent: DefaultIndentation,
+		out:    os.Stdout,
+	}
+	for _, fn := range opts {
+		if err := fn(&options); err != nil {
+			return nil, fmt.Errorf("unable to create the Json Stream Writer: %w", err)
+		}
+	}
+
+	return &StreamWriter{
+		Options: options,
+		field:   field,
+	}, nil
+}
+
+// Write prints the next element of the array
+func (w *StreamWriter) Write(item any) error {
+	b, err := json.MarshalIndent(item, w.indent+w.indent, w.indent)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	separator := ",\n"
+	if w.items == 0 {
+		separator = w.header() + "\n"
+	}
+	w.items++
+
+	_, err = fmt.Fprintf(w.out, "%s%s%s", separator, w.indent+w.indent, b)
+	return err
+}
+
+// Close terminates the array and prints the fields of rest, which has to serialize to a JSON object
+func (w *StreamWriter) Close(rest any) error {
+	b, err := json.MarshalIndent(rest, "", w.indent)
+	if err != nil {
+		return err
+	}
+	fields := strings.TrimSuffix(strings.TrimPrefix(string(b), "{\n"), "\n}")
+	if fields == "{}" {
+		fields = ""
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	var sb strings.Builder
+	if w.items == 0 {
+		sb.WriteString(w.header())
+	} else {
+		sb.WriteString("\n" + w.indent)
+	}
+	sb.WriteString("]")
+	if fields != "" {
+		sb.WriteString(",\n" + fields)
+	}
+	sb.WriteString("\n}\n")
+
+	_, err = fmt.Fprint(w.out, sb.String())
+	return err
+}
+
+func (w *StreamWriter) header() string {
+	name, _ := json.Marshal(w.field)
+	return fmt.Sprintf("{\n%s%s: [", w.indent, name)
+}
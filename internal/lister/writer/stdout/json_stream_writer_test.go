@@ -0,0 +1,51 @@
+package stdout
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type trailer struct {
+	Errors []string `json:"errors"`
+	Count  int      `json:"count"`
+}
+
+func TestStreamWriter(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewStreamWriter("resources", WithOutput(&out))
+	assert.Nil(t, err)
+
+	assert.Nil(t, w.Write(map[string]any{"arn": "arn:aws:s3:::bucket-1", "tags": map[string]string{"a": "b"}}))
+	assert.Nil(t, w.Write(map[string]any{"arn": "arn:aws:s3:::bucket-2"}))
+	assert.Nil(t, w.Close(trailer{Errors: []string{}, Count: 2}))
+
+	assert.Equal(t, `{
+	"resources": [
+		{
+			"arn": "arn:aws:s3:::bucket-1",
+			"tags": {
+				"a": "b"
+			}
+		},
+		{
+			"arn": "arn:aws:s3:::bucket-2"
+		}
+	],
+	"errors": [],
+	"count": 2
+}
+`, out.String())
+}
+
+func TestEmptyStream(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewStreamWriter("resources", WithOutput(&out), WithIndentation(""))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close(struct{}{}))
+
+	var decoded map[string]any
+	assert.Nil(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, map[string]any{"resources": []any{}}, decoded)
+}
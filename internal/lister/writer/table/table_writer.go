@@ -0,0 +1,238 @@
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/vcsomor/aws-resources/internal/lister"
+	"github.com/vcsomor/aws-resources/internal/lister/writer"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	columnID           = "id"
+	columnRegion       = "region"
+	columnCreationTime = "creationTime"
+
+	columnSeparator = "  "
+)
+
+type Options struct {
+	columns  []string
+	markdown bool
+	out      io.Writer
+}
+
+type OptionFnc func(*Options) error
+
+// WithColumns adds columns of flattened keys e.g.: "properties.engine" after the id, the region and the
+// creation time, a column is left out of the table of a resource type which has no such value
+func WithColumns(columns ...string) OptionFnc {
+	return func(options *Options) error {
+		options.columns = append(options.columns, columns...)
+		return nil
+	}
+}
+
+// WithMarkdown prints the tables as Markdown tables instead of plain text
+func WithMarkdown() OptionFnc {
+	return func(options *Options) error {
+		options.markdown = true
+		return nil
+	}
+}
+
+// WithOutput sets where the tables are printed, the default is the standard output
+func WithOutput(out io.Writer) OptionFnc {
+	return func(options *Options) error {
+		if out == nil {
+			return fmt.Errorf("the output is missing")
+		}
+		options.out = out
+		return nil
+	}
+}
+
+type tableWriter struct {
+	opts Options
+}
+
+var _ writer.Writer = (*tableWriter)(nil)
+
+// NewWriter creates a writer which prints a list of results as a column-aligned table per resource type
+func NewWriter(opts ...OptionFnc) (writer.Writer, error) {
+	options := Options{
+		out: os.Stdout,
+	}
+	for _, fn := range opts {
+		if err := fn(&options); err != nil {
+			return nil, fmt.Errorf("unable to create the Table Writer: %w", err)
+		}
+	}
+
+	return &tableWriter{
+		opts: options,
+	}, nil
+}
+
+func (w tableWriter) Write(obj any) error {
+	results, ok := obj.([]lister.Result)
+	if !ok {
+		return fmt.Errorf("unsupported type %T, the Table Writer prints a list of Results", obj)
+	}
+
+	byType := map[string][]lister.Result{}
+	var types []string
+	for _, r := range results {
+		t := r.ResourceType()
+		if _, exist := byType[t]; !exist {
+			types = append(types, t)
+		}
+		byType[t] = append(byType[t], r)
+	}
+	slices.Sort(types)
+
+	var buf bytes.Buffer
+	for i, t := range types {
+		header, rows, err := w.tableOf(byType[t])
+		if err != nil {
+			return err
+		}
+
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		if w.opts.markdown {
+			writeMarkdown(&buf, t, header, rows)
+		} else {
+			writePlain(&buf, t, header, rows)
+		}
+	}
+
+	_, err := w.opts.out.Write(buf.Bytes())
+	return err
+}
+
+func (w tableWriter) tableOf(results []lister.Result) ([]string, [][]string, error) {
+	var flattened []map[string]any
+	for _, r := range results {
+		flat, err := lister.FlattenJSON(r)
+		if err != nil {
+			return nil, nil, err
+		}
+		flattened = append(flattened, flat)
+	}
+
+	header := []string{columnID, columnRegion, columnCreationTime}
+	for _, c := range w.opts.columns {
+		if slices.Contains(header, c) || !anyHas(flattened, c) {
+			continue
+		}
+		header = append(header, c)
+	}
+
+	rows := make([][]string, len(results))
+	for i, r := range results {
+		row := []string{r.ID, r.Region(), formatTime(r.CreationTime)}
+		for _, c := range header[len(row):] {
+			row = append(row, lister.FormatValue(flattened[i][c]))
+		}
+		rows[i] = row
+	}
+	return header, rows, nil
+}
+
+func writePlain(buf *bytes.Buffer, resourceType string, header []string, rows [][]string) {
+	fmt.Fprintf(buf, "%s (%d)\n", resourceType, len(rows))
+
+	upper := make([]string, len(header))
+	for i, h := range header {
+		upper[i] = strings.ToUpper(h)
+	}
+
+	widths := widthsOf(upper, rows)
+	writeLine(buf, "", columnSeparator, "", upper, widths)
+	for _, row := range rows {
+		writeLine(buf, "", columnSeparator, "", row, widths)
+	}
+}
+
+func writeMarkdown(buf *bytes.Buffer, resourceType string, header []string, rows [][]string) {
+	fmt.Fprintf(buf, "## %s (%d)\n\n", resourceType, len(rows))
+
+	escaped := make([][]string, len(rows))
+	for i, row := range rows {
+		escaped[i] = make([]string, len(row))
+		for j, v := range row {
+			escaped[i][j] = escapeMarkdown(v)
+		}
+	}
+
+	widths := widthsOf(header, escaped)
+	rule := make([]string, len(header))
+	for i, width := range widths {
+		// a rule shorter than three dashes is not recognized as a table
+		widths[i] = max(width, 3)
+		rule[i] = strings.Repeat("-", widths[i])
+	}
+
+	writeLine(buf, "| ", " | ", " |", header, widths)
+	writeLine(buf, "| ", " | ", " |", rule, widths)
+	for _, row := range escaped {
+		writeLine(buf, "| ", " | ", " |", row, widths)
+	}
+}
+
+func writeLine(buf *bytes.Buffer, prefix, separator, suffix string, cells []string, widths []int) {
+	var line strings.Builder
+	line.WriteString(prefix)
+	for i, cell := range cells {
+		if i > 0 {
+			line.WriteString(separator)
+		}
+		line.WriteString(cell)
+		if i < len(cells)-1 || suffix != "" {
+			line.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)))
+		}
+	}
+	line.WriteString(suffix)
+	buf.WriteString(strings.TrimRight(line.String(), " "))
+	buf.WriteString("\n")
+}
+
+func widthsOf(header []string, rows [][]string) []int {
+	widths := make([]int, len(header))
+	for i, h := range header {
+		widths[i] = utf8.RuneCountInString(h)
+	}
+	for _, row := range rows {
+		for i, v := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(v))
+		}
+	}
+	return widths
+}
+
+func anyHas(flattened []map[string]any, column string) bool {
+	for _, flat := range flattened {
+		if v, exist := flat[column]; exist && v != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func escapeMarkdown(v string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(v)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
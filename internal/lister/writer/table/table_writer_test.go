@@ -0,0 +1,82 @@
+package table
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/vcsomor/aws-resources/internal/lister"
+	"testing"
+	"time"
+)
+
+func results() []lister.Result {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	engine, status := "postgres", "available"
+	return []lister.Result{
+		{
+			Arn:          "arn:aws:rds:eu-west-1:123456789012:db:my-db",
+			Type:         "rds",
+			ID:           "my-db",
+			CreationTime: &created,
+			Properties:   lister.RDSData{Engine: &engine, Status: &status},
+		},
+		{
+			Arn:        "arn:aws:s3:::bucket|1",
+			Type:       "s3",
+			ID:         "bucket|1",
+			Properties: lister.S3Data{Region: "us-east-2"},
+		},
+		{
+			Arn:        "arn:aws:rds:us-east-1:123456789012:db:other-db",
+			Type:       "rds",
+			ID:         "other-db",
+			Properties: lister.RDSData{Status: &status},
+		},
+	}
+}
+
+func TestPlainTable(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewWriter(WithOutput(&out), WithColumns("properties.engine", "properties.status"))
+	assert.Nil(t, err)
+
+	assert.Nil(t, w.Write(results()))
+	assert.Equal(t,
+		"rds (2)\n"+
+			"ID        REGION     CREATIONTIME          PROPERTIES.ENGINE  PROPERTIES.STATUS\n"+
+			"my-db     eu-west-1  2024-05-01T10:00:00Z  postgres           available\n"+
+			"other-db  us-east-1                                           available\n"+
+			"\n"+
+			"s3 (1)\n"+
+			"ID        REGION     CREATIONTIME\n"+
+			"bucket|1  us-east-2\n",
+		out.String())
+}
+
+func TestMarkdownTable(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewWriter(WithOutput(&out), WithMarkdown(), WithColumns("properties.engine"))
+	assert.Nil(t, err)
+
+	assert.Nil(t, w.Write(results()))
+	assert.Equal(t,
+		"## rds (2)\n\n"+
+			"| id       | region    | creationTime         | properties.engine |\n"+
+			"| -------- | --------- | -------------------- | ----------------- |\n"+
+			"| my-db    | eu-west-1 | 2024-05-01T10:00:00Z | postgres          |\n"+
+			"| other-db | us-east-1 |                      |                   |\n"+
+			"\n"+
+			"## s3 (1)\n\n"+
+			"| id        | region    | creationTime |\n"+
+			"| --------- | --------- | ------------ |\n"+
+			"| bucket\\|1 | us-east-2 |              |\n",
+		out.String())
+}
+
+func TestUnsupportedData(t *testing.T) {
+	w, err := NewWriter(WithOutput(&bytes.Buffer{}))
+	assert.Nil(t, err)
+	assert.NotNil(t, w.Write(lister.Result{}))
+
+	_, err = NewWriter(WithOutput(nil))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,151 @@
+package template
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/vcsomor/aws-resources/internal/lister"
+	"github.com/vcsomor/aws-resources/internal/lister/writer"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	gotemplate "text/template"
+	"time"
+)
+
+// Data is what the cmd layer renders through the template, any other value can be written as well
+type Data struct {
+	Resources []lister.Result
+	Errors    []lister.ResourceError
+	Manifest  lister.Manifest
+}
+
+type Options struct {
+	out io.Writer
+}
+
+type OptionFnc func(*Options) error
+
+// WithOutput sets where the template is rendered to, the default is the standard output
+func WithOutput(out io.Writer) OptionFnc {
+	return func(options *Options) error {
+		if out == nil {
+			return fmt.Errorf("the output is missing")
+		}
+		options.out = out
+		return nil
+	}
+}
+
+type templateWriter struct {
+	opts Options
+	tmpl *gotemplate.Template
+	mu   sync.Mutex
+}
+
+var _ writer.Writer = (*templateWriter)(nil)
+
+// NewWriter parses the text/template file, every written object is rendered through it. Besides the
+// functions of text/template the template can use:
+//
+//	tag         the value of a tag of a result or "": {{tag . "Owner"}}
+//	hasTag      whether a result has a tag: {{if hasTag . "Owner"}}
+//	ofType      the results of a resource type: {{range ofType "rds" .Resources}}
+//	arn         the parts of an ARN: {{(arn .Arn).Region}}, {{(arn .Arn).AccountID}}
+//	time        formats a time or a time pointer with a Go layout: {{time "2006-01-02" .CreationTime}}
+//	json        a value as compact JSON: {{json .Properties}}
+//	jsonIndent  a value as indented JSON: {{jsonIndent .Properties}}
+func NewWriter(templateFile string, opts ...OptionFnc) (writer.Writer, error) {
+	options := Options{
+		out: os.Stdout,
+	}
+	for _, fn := range opts {
+		if err := fn(&options); err != nil {
+			return nil, fmt.Errorf("unable to create the Template Writer: %w", err)
+		}
+	}
+
+	b, err := os.ReadFile(templateFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the template: %w", err)
+	}
+
+	tmpl, err := gotemplate.New(filepath.Base(templateFile)).
+		Funcs(funcs()).
+		Parse(string(b))
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the template: %w", err)
+	}
+
+	return &templateWriter{
+		opts: options,
+		tmpl: tmpl,
+	}, nil
+}
+
+func (w *templateWriter) Write(obj any) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.tmpl.Execute(w.opts.out, obj)
+}
+
+func funcs() gotemplate.FuncMap {
+	return gotemplate.FuncMap{
+		"tag":        tag,
+		"hasTag":     hasTag,
+		"ofType":     ofType,
+		"arn":        arn.Parse,
+		"time":       formatTime,
+		"json":       toJSON,
+		"jsonIndent": toIndentedJSON,
+	}
+}
+
+func tag(r lister.Result, key string) string {
+	if v := r.Tags()[key]; v != nil {
+		return *v
+	}
+	return ""
+}
+
+func hasTag(r lister.Result, key string) bool {
+	_, exist := r.Tags()[key]
+	return exist
+}
+
+func ofType(resourceType string, results []lister.Result) []lister.Result {
+	var filtered []lister.Result
+	for _, r := range results {
+		if r.ResourceType() == resourceType {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+func formatTime(layout string, t any) (string, error) {
+	switch v := t.(type) {
+	case nil:
+		return "", nil
+	case time.Time:
+		return v.Format(layout), nil
+	case *time.Time:
+		if v == nil {
+			return "", nil
+		}
+		return v.Format(layout), nil
+	default:
+		return "", fmt.Errorf("unsupported time type %T", t)
+	}
+}
+
+func toJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+func toIndentedJSON(v any) (string, error) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	return string(b), err
+}
@@ -0,0 +1,85 @@
+package template
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/vcsomor/aws-resources/internal/lister"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeTemplate(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "report.tmpl")
+	assert.Nil(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestRenderData(t *testing.T) {
+	created := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	owner, engine := "team-a", "postgres"
+
+	path := writeTemplate(t, `Account {{.Manifest.Account}}
+{{range ofType "rds" .Resources -}}
+* {{.ID}} in {{(arn .Arn).Region}} of {{(arn .Arn).AccountID}}, created {{time "2006-01-02" .CreationTime}}, owner {{tag . "Owner"}}
+  {{json .Properties.Engine}}{{if not (hasTag . "CostCenter")}} no cost center{{end}}
+{{end -}}
+{{range .Resources}}{{if eq .ResourceType "s3"}}bucket {{.ID}} {{time "2006" .CreationTime}}-{{tag . "Owner"}}{{end}}{{end}}
+`)
+
+	var out bytes.Buffer
+	w, err := NewWriter(path, WithOutput(&out))
+	assert.Nil(t, err)
+
+	assert.Nil(t, w.Write(Data{
+		Manifest: lister.Manifest{Account: "123456789012"},
+		Resources: []lister.Result{
+			{
+				Arn:          "arn:aws:rds:eu-west-1:123456789012:db:my-db",
+				Type:         "rds",
+				ID:           "my-db",
+				CreationTime: &created,
+				Properties:   lister.RDSData{Engine: &engine, Tags: map[string]*string{"Owner": &owner}},
+			},
+			{
+				Arn:        "arn:aws:s3:::my-bucket",
+				Type:       "s3",
+				ID:         "my-bucket",
+				Properties: lister.S3Data{},
+			},
+		},
+	}))
+
+	assert.Equal(t, `Account 123456789012
+* my-db in eu-west-1 of 123456789012, created 2024-05-01, owner team-a
+  "postgres" no cost center
+bucket my-bucket -
+`, out.String())
+}
+
+func TestJSONIndent(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewWriter(writeTemplate(t, `{{jsonIndent .}}`), WithOutput(&out))
+	assert.Nil(t, err)
+
+	assert.Nil(t, w.Write(map[string]int{"a": 1}))
+	assert.Equal(t, "{\n  \"a\": 1\n}", out.String())
+}
+
+func TestInvalidTemplate(t *testing.T) {
+	_, err := NewWriter(writeTemplate(t, `{{range}}`))
+	assert.NotNil(t, err)
+
+	_, err = NewWriter(filepath.Join(t.TempDir(), "missing.tmpl"))
+	assert.NotNil(t, err)
+}
+
+func TestFormatTime(t *testing.T) {
+	_, err := formatTime(time.RFC3339, "yesterday")
+	assert.NotNil(t, err)
+
+	s, err := formatTime(time.RFC3339, (*time.Time)(nil))
+	assert.Nil(t, err)
+	assert.Equal(t, "", s)
+}
@@ -0,0 +1,83 @@
+package yaml
+
+import (
+	"fmt"
+	"github.com/vcsomor/aws-resources/internal/lister/writer"
+	"strings"
+	"sync"
+)
+
+// StreamWriter prints a YAML mapping whose sequence field is written element by element as the elements
+// arrive, Close completes the mapping with the rest of its fields. It is safe for concurrent use.
+type StreamWriter struct {
+	opts Options
+
+	mu    sync.Mutex
+	field string
+	items int
+}
+
+var _ writer.Writer = (*StreamWriter)(nil)
+
+func NewStreamWriter(field string, opts ...OptionFnc) (*StreamWriter, error) {
+	options, err := newOptions(opts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the YAML Stream Writer: %w", err)
+	}
+
+	return &StreamWriter{
+		opts:  options,
+		field: field,
+	}, nil
+}
+
+// Write prints the next element of the sequence
+func (w *StreamWriter) Write(item any) error {
+	b, err := marshal([]any{item}, w.opts.indent)
+	if err != nil {
+		return err
+	}
+	element := indentLines(string(b), strings.Repeat(" ", w.opts.indent))
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.items == 0 {
+		element = w.field + ":\n" + element
+	}
+	w.items++
+
+	_, err = fmt.Fprint(w.opts.out, element)
+	return err
+}
+
+// Close terminates the sequence and prints the fields of rest, which has to serialize to a mapping
+func (w *StreamWriter) Close(rest any) error {
+	b, err := marshal(rest, w.opts.indent)
+	if err != nil {
+		return err
+	}
+	fields := string(b)
+	if fields == "{}\n" {
+		fields = ""
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.items == 0 {
+		fields = w.field + ": []\n" + fields
+	}
+	_, err = fmt.Fprint(w.opts.out, fields)
+	return err
+}
+
+func indentLines(s string, indent string) string {
+	lines := strings.SplitAfter(s, "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = indent + l
+		}
+	}
+	return strings.Join(lines, "")
+}
@@ -0,0 +1,135 @@
+package yaml
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/vcsomor/aws-resources/internal/lister/writer"
+	goyaml "gopkg.in/yaml.v3"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	DefaultOutputFile  = "resource.yaml"
+	DefaultIndentation = 2
+)
+
+type Options struct {
+	outputFile string
+	indent     int
+	out        io.Writer
+}
+
+type OptionFnc func(*Options) error
+
+func WithOutputFile(outputFile string) OptionFnc {
+	return func(options *Options) error {
+		if !filepath.IsLocal(outputFile) {
+			return fmt.Errorf("the file is not a relative filename %s", outputFile)
+		}
+		options.outputFile = outputFile
+		return nil
+	}
+}
+
+// WithIndentation sets the number of spaces a nested level is indented with
+func WithIndentation(spaces int) OptionFnc {
+	return func(options *Options) error {
+		if spaces < 2 || spaces > 9 {
+			return fmt.Errorf("the indentation has to be between 2 and 9 spaces: %d", spaces)
+		}
+		options.indent = spaces
+		return nil
+	}
+}
+
+// WithOutput replaces the standard output of the stream writer e.g.: in tests
+func WithOutput(out io.Writer) OptionFnc {
+	return func(options *Options) error {
+		options.out = out
+		return nil
+	}
+}
+
+type yamlFileWriter struct {
+	to   string
+	opts Options
+}
+
+var _ writer.Writer = (*yamlFileWriter)(nil)
+
+// NewWriter writes the objects into the output file of the directory
+func NewWriter(to string, opts ...OptionFnc) (writer.Writer, error) {
+	options, err := newOptions(opts)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the YAML File Writer: %w", err)
+	}
+
+	return &yamlFileWriter{
+		to:   to,
+		opts: options,
+	}, nil
+}
+
+func (w yamlFileWriter) Write(obj any) error {
+	b, err := marshal(obj, w.opts.indent)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(w.to, os.ModePerm); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(w.to, w.opts.outputFile), b, 0o644)
+}
+
+func newOptions(opts []OptionFnc) (Options, error) {
+	options := Options{
+		outputFile: DefaultOutputFile,
+		indent:     DefaultIndentation,
+		out:        os.Stdout,
+	}
+	for _, fn := range opts {
+		if err := fn(&options); err != nil {
+			return Options{}, err
+		}
+	}
+	return options, nil
+}
+
+// marshal serializes the object through its JSON form, so the YAML keys are the JSON field names
+// and keep their order
+func marshal(obj any, indent int) ([]byte, error) {
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc goyaml.Node
+	if err = goyaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	blockStyle(&doc)
+
+	var buf bytes.Buffer
+	enc := goyaml.NewEncoder(&buf)
+	enc.SetIndent(indent)
+	if err = enc.Encode(&doc); err != nil {
+		return nil, err
+	}
+	if err = enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// blockStyle drops the JSON flow style and quoting, the encoder still quotes the strings which would
+// read as another type
+func blockStyle(n *goyaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
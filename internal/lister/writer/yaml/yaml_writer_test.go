@@ -0,0 +1,93 @@
+package yaml
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	goyaml "gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testResource struct {
+	Arn          string             `json:"arn"`
+	ID           string             `json:"id"`
+	CreationTime *time.Time         `json:"creationTime"`
+	Size         int                `json:"size"`
+	Tags         map[string]*string `json:"tags"`
+	Warnings     []string           `json:"warnings,omitempty"`
+}
+
+func aResource() testResource {
+	created := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+	owner := "true"
+	return testResource{
+		Arn:          "arn:aws:s3:::bucket-1",
+		ID:           "bucket-1",
+		CreationTime: &created,
+		Size:         10,
+		Tags:         map[string]*string{"Owner": &owner, "Empty": nil},
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	root := t.TempDir()
+	w, err := NewWriter(root, WithOutputFile("bucket-1.yaml"), WithIndentation(4))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Write(aResource()))
+
+	b, err := os.ReadFile(filepath.Join(root, "bucket-1.yaml"))
+	assert.Nil(t, err)
+	assert.Equal(t, `arn: arn:aws:s3:::bucket-1
+id: bucket-1
+creationTime: "2024-03-01T10:00:00Z"
+size: 10
+tags:
+    Empty: null
+    Owner: "true"
+`, string(b))
+}
+
+func TestOptions(t *testing.T) {
+	_, err := NewWriter(t.TempDir(), WithOutputFile("../escape.yaml"))
+	assert.NotNil(t, err)
+
+	_, err = NewStreamWriter("resources", WithIndentation(1))
+	assert.NotNil(t, err)
+}
+
+func TestStreamWriter(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewStreamWriter("resources", WithOutput(&out))
+	assert.Nil(t, err)
+
+	assert.Nil(t, w.Write(aResource()))
+	assert.Nil(t, w.Write(map[string]any{"arn": "arn:aws:s3:::bucket-2"}))
+	assert.Nil(t, w.Close(map[string]any{"errors": []any{}}))
+
+	assert.Equal(t, `resources:
+  - arn: arn:aws:s3:::bucket-1
+    id: bucket-1
+    creationTime: "2024-03-01T10:00:00Z"
+    size: 10
+    tags:
+      Empty: null
+      Owner: "true"
+  - arn: arn:aws:s3:::bucket-2
+errors: []
+`, out.String())
+
+	var decoded map[string]any
+	assert.Nil(t, goyaml.Unmarshal(out.Bytes(), &decoded))
+	assert.Len(t, decoded["resources"], 2)
+}
+
+func TestEmptyStream(t *testing.T) {
+	var out bytes.Buffer
+	w, err := NewStreamWriter("resources", WithOutput(&out))
+	assert.Nil(t, err)
+	assert.Nil(t, w.Close(struct{}{}))
+
+	assert.Equal(t, "resources: []\n", out.String())
+}